@@ -0,0 +1,65 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/FFX01/gettuit/internal/gotuit"
+)
+
+// Config holds user settings loaded from config.json in the gettuit config
+// directory. Every field is optional.
+type Config struct {
+	// Keymap maps view name, then mode name, then action id to the keys bound to
+	// that action, for example {"Todo List": {"normal": {"delete": ["x"]}}}.
+	Keymap map[string]map[string]map[string][]string `json:"keymap"`
+	// GlobalKeymap maps global action ids to keys.
+	GlobalKeymap map[string][]string `json:"global_keymap"`
+	// Theme is the name of the theme used at startup.
+	Theme string `json:"theme"`
+	// Themes are user defined themes, mapping theme name to the style of each
+	// role. Roles left out use the style of the default theme.
+	Themes map[string]map[gotuit.Role]gotuit.StyleSpec `json:"themes"`
+	// DisableConfirmations skips the dialog asking to confirm deleting todos and
+	// quitting with unsaved changes.
+	DisableConfirmations bool `json:"disable_confirmations"`
+}
+
+func configDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "gettuit"), nil
+}
+
+// loadConfig reads the config file. A missing config file is not an error.
+func loadConfig() (Config, error) {
+	config := Config{}
+
+	dir, err := configDir()
+	if err != nil {
+		return config, err
+	}
+
+	file, err := os.Open(filepath.Join(dir, "config.json"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return config, nil
+	}
+	if err != nil {
+		return config, err
+	}
+	defer file.Close()
+
+	decoder := json.NewDecoder(file)
+	decoder.DisallowUnknownFields()
+	err = decoder.Decode(&config)
+	if err != nil {
+		return config, err
+	}
+
+	return config, nil
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+// Filter narrows the todos rendered in the "Todo List" view. A filter query is a
+// space separated list of terms which must all match. Supported terms are:
+//
+//	is:done, is:open     completion status
+//	#tag                 todo text contains the tag
+//	due:today            due today
+//	due:overdue          open and due before today
+//	due:none             no due date
+//	due:YYYY-MM-DD       due on the given date
+//	created:today        created today
+//	created:YYYY-MM-DD   created on the given date
+//	anything else        case insensitive text match
+//
+// Any term may be prefixed with '-' to negate it.
+type Filter struct {
+	Query string
+	// Name is set when the filter belongs to a saved view.
+	Name  string
+	terms []filterTerm
+}
+
+type filterTerm struct {
+	negate bool
+	match  func(t Todo, today time.Time) bool
+}
+
+func parseFilter(query string) (*Filter, error) {
+	f := Filter{Query: strings.TrimSpace(query)}
+
+	for _, word := range strings.Fields(query) {
+		term := filterTerm{}
+		if len(word) > 1 && strings.HasPrefix(word, "-") {
+			term.negate = true
+			word = word[1:]
+		}
+
+		match, err := parseFilterTerm(word)
+		if err != nil {
+			return nil, err
+		}
+		term.match = match
+		f.terms = append(f.terms, term)
+	}
+
+	return &f, nil
+}
+
+func parseFilterTerm(word string) (func(Todo, time.Time) bool, error) {
+	key, value, found := strings.Cut(word, ":")
+	if !found || value == "" {
+		if len(word) > 1 && strings.HasPrefix(word, "#") {
+			tag := strings.ToLower(word[1:])
+			return func(t Todo, _ time.Time) bool {
+				return t.hasTag(tag)
+			}, nil
+		}
+		text := strings.ToLower(word)
+		return func(t Todo, _ time.Time) bool {
+			return strings.Contains(strings.ToLower(t.text), text)
+		}, nil
+	}
+
+	switch key {
+	case "is":
+		switch value {
+		case "done", "complete":
+			return func(t Todo, _ time.Time) bool { return t.complete }, nil
+		case "open", "todo":
+			return func(t Todo, _ time.Time) bool { return !t.complete }, nil
+		}
+		return nil, fmt.Errorf("Unknown status '%s'", value)
+	case "due":
+		switch value {
+		case "none":
+			return func(t Todo, _ time.Time) bool {
+				_, ok := t.due()
+				return !ok
+			}, nil
+		case "overdue":
+			return func(t Todo, today time.Time) bool {
+				due, ok := t.due()
+				return ok && !t.complete && due.Before(today)
+			}, nil
+		}
+		return parseDateTerm(value, func(t Todo) (time.Time, bool) { return t.due() })
+	case "created":
+		return parseDateTerm(value, func(t Todo) (time.Time, bool) {
+			return t.created, !t.created.IsZero()
+		})
+	}
+
+	text := strings.ToLower(word)
+	return func(t Todo, _ time.Time) bool {
+		return strings.Contains(strings.ToLower(t.text), text)
+	}, nil
+}
+
+func parseDateTerm(value string, date func(Todo) (time.Time, bool)) (func(Todo, time.Time) bool, error) {
+	if value == "today" {
+		return func(t Todo, today time.Time) bool {
+			d, ok := date(t)
+			return ok && sameDay(d, today)
+		}, nil
+	}
+
+	day, err := time.ParseInLocation(dateLayout, value, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid date '%s', expected YYYY-MM-DD", value)
+	}
+	return func(t Todo, _ time.Time) bool {
+		d, ok := date(t)
+		return ok && sameDay(d, day)
+	}, nil
+}
+
+// Match reports whether t satisfies every term of the filter, with today being
+// the start of the current day. Todos that are currently being edited always
+// match so they don't vanish mid edit.
+func (f *Filter) Match(t Todo, today time.Time) bool {
+	if t.temp {
+		return true
+	}
+
+	for _, term := range f.terms {
+		if term.match(t, today) == term.negate {
+			return false
+		}
+	}
+	return true
+}
+
+func startOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+func sameDay(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd
+}
@@ -3,68 +3,71 @@ package gotuit
 import (
 	"errors"
 	"fmt"
-	"log"
 	"log/slog"
 	"os"
+	"runtime/debug"
+	"sync"
 
 	"github.com/gdamore/tcell/v2"
 )
 
 type App struct {
-	screen      tcell.Screen
-	quit        bool
-	focusedView string
-	views       []*View
-	logs        []string
-	keybinds    []GlobalKeybind
-}
-
-func (app *App) Write(p []byte) (n int, err error) {
-	n = len(p)
-	app.logs = append(app.logs, string(p))
-	return n, nil
-}
-
-func (app *App) PopLog() string {
-	if len(app.logs) < 1 {
-		return "none"
-	}
-
-	v := app.logs[0]
-	app.logs = app.logs[1:]
-	return v
+	screen       tcell.Screen
+	quit         bool
+	views        []*View
+	status       statusQueue
+	keybinds     []GlobalKeybind
+	theme        *Theme
+	mouseButtons tcell.ButtonMask
+	mouseTarget  *View
+	modals       []*View
+	clock        Clock
+	clear        bool
+	focus        []*View
+	notified     *View
+	inputState   State
+	statusState  State
+	// invalidations counts calls to View.Invalidate and App.Invalidate, to tell
+	// whether a posted function invalidated anything.
+	invalidations int
+	queueMu       sync.Mutex
+	queued        []func(*App)
 }
 
 type GlobalKeybind struct {
 	name        string
 	description string
-	key         tcell.Key
-	callback    func(*App)
+	key         KeyChord
+	callback    func(*App) error
 }
 
-func NewApp() *App {
+// NewApp takes over the terminal. Call App.Cleanup to give it back.
+func NewApp() (*App, error) {
 	screen, err := tcell.NewScreen()
 	if err != nil {
-		log.Fatal("Unable to draw screen", "error", err)
-		os.Exit(1)
+		return nil, fmt.Errorf("Unable to create screen: %w", err)
 	}
+	return NewAppWithScreen(screen)
+}
 
-	err = screen.Init()
+// NewAppWithScreen creates an App drawing to screen, like a
+// tcell.SimulationScreen in tests and benchmarks.
+func NewAppWithScreen(screen tcell.Screen) (*App, error) {
+	err := screen.Init()
 	if err != nil {
-		log.Fatal("Unable to initialize screen", "error", err)
-		os.Exit(1)
+		return nil, fmt.Errorf("Unable to initialize screen: %w", err)
 	}
+	screen.EnableMouse()
 
 	app := App{
 		screen: screen,
-        logs: make([]string, 0),
+		clock:  systemClock{},
+		clear:  true,
+		focus:  []*View{nil},
 	}
+	app.SetTheme(DefaultTheme())
 
-	if err != nil {
-		slog.Warn("Unable to load from disk. File may not exist", "error", err)
-	}
-
-	return &app
+	return &app, nil
 }
 
 func (app *App) Cleanup() {
@@ -75,43 +78,62 @@ func (app *App) Size() (w int, h int) {
 	return app.screen.Size()
 }
 
-func (app *App) MainLoop() {
+// PanicError is returned by App.MainLoop when a callback panics.
+type PanicError struct {
+	Value any
+	Stack []byte
+}
+
+func (err *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v\n\n%s", err.Value, err.Stack)
+}
+
+// MainLoop draws the views and handles events until App.Quit is called. If
+// anything panics on the way, the terminal is given back so the panic can be
+// reported, and MainLoop returns a *PanicError holding the stack trace.
+func (app *App) MainLoop() (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			app.Cleanup()
+			err = &PanicError{Value: r, Stack: debug.Stack()}
+		}
+	}()
+
 	for !app.quit {
 		app.Draw()
 		app.handleEvent(app.screen.PollEvent())
+		app.runQueued()
 	}
+	return nil
 }
 
-func (app *App) AddView(v *View) {
-	v.App = app
-	app.views = append(app.views, v)
+// reportError shows err, if there is one, as an error in the status line.
+func (app *App) reportError(err error) {
+	if err == nil {
+		return
+	}
+	slog.Debug("Callback failed", "error", err)
+	app.Notify(SeverityError, err.Error())
 }
 
-func (app *App) Focus(viewName string) error {
-    for _, v := range app.views {
-        if v.Name == viewName {
-            app.focusedView = viewName
-            slog.Debug("Switching focus", "view", app.focusedView)
-            return nil
-        }
-    }
-    return fmt.Errorf("View with name '%s' does not exist", viewName)
+// AddView adds v, along with its descendants, to the top level views of app.
+func (app *App) AddView(v *View) {
+	v.setApp(app)
+	app.views = append(app.views, v)
+	app.invalidateLayout()
 }
 
+// GetView returns the view at name, looked up like App.Focus.
 func (app *App) GetView(name string) (view *View, ok bool) {
-	for _, v := range app.views {
-		if v.Name == name {
-			return v, true
-		}
-	}
-	return nil, false
+	v := app.findView(name)
+	return v, v != nil
 }
 
+// GetFocusedView returns the view receiving key events: the focused view of the
+// topmost modal if one is open, otherwise the focused view.
 func (app *App) GetFocusedView() (*View, error) {
-	for _, v := range app.views {
-		if v.Name == app.focusedView {
-			return v, nil
-		}
+	if v := app.focusedLeaf(); v != nil {
+		return v, nil
 	}
 	return nil, errors.New("View not found")
 }
@@ -122,24 +144,29 @@ func (app *App) Quit() {
 
 func (app *App) handleEvent(ev tcell.Event) {
 	switch ev := ev.(type) {
+	case *tcell.EventResize:
+		app.invalidateLayout()
+		return
 	case *tcell.EventKey:
-		var key tcell.Key
-		if ev.Key() == tcell.KeyRune {
-			key = tcell.Key(ev.Rune())
-		} else {
-			key = ev.Key()
-		}
-		kb, err := app.getKeybind(key)
+		invalidatePath(app.focusedLeaf())
+		app.inputState.Changed()
+		kb, err := app.getKeybind(NewKeyChord(ev))
 		if err == nil {
-			kb.callback(app)
-			return
+			err = kb.callback(app)
+			if !errors.Is(err, Propagate) {
+				app.reportError(err)
+				return
+			}
 		}
-	}
-
-	focusedView, err := app.GetFocusedView()
-	if err == nil {
-		focusedView.handleEvent(ev)
-		return
+		app.dispatchKey(ev)
+	case *tcell.EventMouse:
+		app.handleMouse(ev)
+	case *funcEvent:
+		app.runFunc(ev)
+	case *wakeEvent:
+		// The queued functions run after every event.
+	default:
+		app.bubble(app.focusPath(), ev)
 	}
 }
 
@@ -163,21 +190,65 @@ func (app *App) HideView(name string) error {
 	return errors.New("View not found")
 }
 
+// Colors returns the number of colors the terminal can display. It returns 0 if
+// the NO_COLOR environment variable is set.
+func (app *App) Colors() int {
+	if os.Getenv("NO_COLOR") != "" {
+		return 0
+	}
+	return app.screen.Colors()
+}
+
+// Theme returns the theme in use, already degraded to the colors the terminal
+// supports.
+func (app *App) Theme() *Theme {
+	return app.theme
+}
+
+func (app *App) SetTheme(theme *Theme) {
+	app.theme = theme.Degrade(app.Colors())
+	app.invalidateLayout()
+}
+
+// Draw renders the views which have been invalidated and puts every view on the
+// screen. It does nothing if no view has been invalidated since the last frame.
+// The screen is only cleared when views may have uncovered parts of it, tcell
+// works out which cells changed and only sends those to the terminal.
 func (app *App) Draw() {
-	app.screen.Clear()
+	app.syncFocus()
+	if !app.needsDraw() {
+		return
+	}
+	if app.clear {
+		app.screen.SetStyle(app.theme.Style(RoleNormal))
+		app.screen.Clear()
+		app.clear = false
+	}
 	for _, v := range app.views {
 		if !v.visible {
 			continue
 		}
-		v.Clear()
-		v.renderFunc(v)
+		v.render()
 		v.Draw(app.screen)
 	}
+	app.drawModals()
 
 	app.screen.Show()
 }
 
-func (app *App) Bind(key tcell.Key, name, description string, cb func(*App)) {
+// Bind binds the chord key, as parsed by ParseKeyChord, to cb regardless of which
+// view has focus. cb can return Propagate to pass the key on to the focused view.
+// Bind panics if key can't be parsed.
+func (app *App) Bind(key string, name, description string, cb func(*App) error) {
+	chord, err := ParseKeyChord(key)
+	if err != nil {
+		panic(fmt.Sprintf("gotuit: invalid keybind '%s': %s", key, err))
+	}
+	app.BindChord(chord, name, description, cb)
+}
+
+// BindChord is like Bind but takes an already parsed chord.
+func (app *App) BindChord(key KeyChord, name, description string, cb func(*App) error) {
 	kb := GlobalKeybind{
 		name:        name,
 		description: description,
@@ -187,7 +258,7 @@ func (app *App) Bind(key tcell.Key, name, description string, cb func(*App)) {
 	app.keybinds = append(app.keybinds, kb)
 }
 
-func (app *App) getKeybind(key tcell.Key) (GlobalKeybind, error) {
+func (app *App) getKeybind(key KeyChord) (GlobalKeybind, error) {
 	for _, kb := range app.keybinds {
 		if kb.key == key {
 			return kb, nil
@@ -0,0 +1,47 @@
+package gotuit
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// newTestApp returns an App drawing to a w by h simulation screen.
+func newTestApp(t testing.TB, w, h int) (*App, tcell.SimulationScreen) {
+	t.Helper()
+	screen := tcell.NewSimulationScreen("")
+	app, err := NewAppWithScreen(screen)
+	if err != nil {
+		t.Fatal(err)
+	}
+	screen.SetSize(w, h)
+	t.Cleanup(app.Cleanup)
+	return app, screen
+}
+
+// mustPost posts fn, waiting for room in the event queue if it is full.
+func mustPost(app *App, fn func(*App)) {
+	for app.Post(fn) != nil {
+		runtime.Gosched()
+	}
+}
+
+// drain runs the main loop until every event posted so far has been handled.
+func drain(t testing.TB, app *App) {
+	t.Helper()
+	mustPost(app, func(app *App) {
+		app.Quit()
+	})
+	if err := app.MainLoop(); err != nil {
+		t.Fatal(err)
+	}
+	app.quit = false
+}
+
+// press sends keys, parsed like View.Bind, to app as if they were typed.
+func press(app *App, keys string) {
+	for _, kc := range mustParseKeys(keys) {
+		app.handleEvent(tcell.NewEventKey(kc.Key, kc.Rune, kc.Mod))
+	}
+}
@@ -0,0 +1,59 @@
+package gotuit
+
+import (
+	"github.com/gdamore/tcell/v2"
+)
+
+// Component is a widget hosted by a View, for widgets which keep their own state
+// rather than rendering from state kept elsewhere. Every method is passed the
+// hosting view.
+type Component interface {
+	// Render draws the component to v.
+	Render(v *View)
+	// HandleEvent is called with the events reaching v before they are matched
+	// against v's keybinds. It returns true if it handled ev, or false to let it
+	// go on to the keybinds. Mouse events go to the view's mouse handler instead.
+	HandleEvent(v *View, ev tcell.Event) bool
+	// Focus is called when v starts receiving key events.
+	Focus(v *View)
+	// Blur is called when v stops receiving key events.
+	Blur(v *View)
+	// Layout is called before the first Render, and before any Render after the
+	// inner size of v changed, so the component can fit itself to v.
+	Layout(v *View)
+}
+
+// BaseComponent implements every Component method except Render as a no-op, so
+// components embedding it only need to implement the methods they use.
+type BaseComponent struct{}
+
+func (BaseComponent) HandleEvent(*View, tcell.Event) bool { return false }
+func (BaseComponent) Focus(*View)                         {}
+func (BaseComponent) Blur(*View)                          {}
+func (BaseComponent) Layout(*View)                        {}
+
+// NewComponentView creates a view hosting c.
+func NewComponentView(name string, x, y, w, h int, c Component) *View {
+	v := NewView(name, x, y, w, h, c.Render)
+	v.component = c
+	return v
+}
+
+// Component returns the component hosted by the view, or nil if it has none.
+func (v *View) Component() Component {
+	return v.component
+}
+
+// layout calls the component's Layout hook if the view's inner size changed
+// since it was last laid out.
+func (v *View) layout() {
+	if v.component == nil {
+		return
+	}
+	w, h := v.InnerWidth(), v.InnerHeight()
+	if v.laidOut && w == v.layoutw && h == v.layouth {
+		return
+	}
+	v.component.Layout(v)
+	v.laidOut, v.layoutw, v.layouth = true, w, h
+}
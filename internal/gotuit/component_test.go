@@ -0,0 +1,86 @@
+package gotuit
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// recordingComponent records every hook called on it. It handles the key
+// events for the runes in handles.
+type recordingComponent struct {
+	events  *[]string
+	handles string
+}
+
+func (c *recordingComponent) record(format string, args ...any) {
+	*c.events = append(*c.events, fmt.Sprintf(format, args...))
+}
+
+func (c *recordingComponent) Render(v *View) {
+	c.record("render %s", v.Name)
+}
+
+func (c *recordingComponent) HandleEvent(v *View, ev tcell.Event) bool {
+	evKey, ok := ev.(*tcell.EventKey)
+	if !ok || !slices.Contains([]rune(c.handles), evKey.Rune()) {
+		return false
+	}
+	c.record("event %c", evKey.Rune())
+	return true
+}
+
+func (c *recordingComponent) Focus(v *View) {
+	c.record("component focus %s", v.Name)
+}
+
+func (c *recordingComponent) Blur(v *View) {
+	c.record("component blur %s", v.Name)
+}
+
+func (c *recordingComponent) Layout(v *View) {
+	c.record("layout %dx%d", v.InnerWidth(), v.InnerHeight())
+}
+
+func TestComponentHandlesEventsBeforeKeybinds(t *testing.T) {
+	app, _ := newTestApp(t, 80, 24)
+	events := []string{}
+	v := NewComponentView("Component", 0, 0, 10, 5, &recordingComponent{events: &events, handles: "x"})
+	for _, key := range []string{"x", "y"} {
+		v.Bind(NormalMode, key, "Keybind", "", func(*View) error {
+			events = append(events, "keybind "+key)
+			return nil
+		})
+	}
+	app.AddView(v)
+	if err := app.Focus("Component"); err != nil {
+		t.Fatal(err)
+	}
+	events = events[:0]
+
+	press(app, "x y")
+	want := []string{"event x", "keybind y"}
+	if !slices.Equal(events, want) {
+		t.Fatalf("Got %q, want %q", events, want)
+	}
+}
+
+func TestComponentLayout(t *testing.T) {
+	app, _ := newTestApp(t, 80, 24)
+	events := []string{}
+	v := NewComponentView("Component", 0, 0, 10, 5, &recordingComponent{events: &events})
+	app.AddView(v)
+
+	app.Draw()
+	v.Invalidate()
+	app.Draw()
+	v.SetPadding(1, 1, 1, 1)
+	v.Invalidate()
+	app.Draw()
+	want := []string{"layout 8x3", "render Component", "render Component", "layout 6x1", "render Component"}
+	if !slices.Equal(events, want) {
+		t.Fatalf("Got %q, want %q", events, want)
+	}
+}
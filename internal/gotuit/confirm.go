@@ -0,0 +1,98 @@
+package gotuit
+
+const (
+	confirmYes = "[ Yes ]"
+	confirmNo  = "[ No ]"
+	// confirmGap is the space between the two buttons.
+	confirmGap = 2
+)
+
+// confirmDialog is the state of a dialog opened by App.Confirm.
+type confirmDialog struct {
+	title   string
+	message string
+	yes     bool
+	cb      func(bool) error
+}
+
+// Confirm opens a modal dialog asking the user to confirm message. cb is called
+// once the dialog closes, with true if the user picked "Yes". An error returned
+// by cb is shown in the status line. "No" is selected when the dialog opens, so
+// a stray Enter never confirms a destructive action.
+//
+// The dialog answers to y and n, Enter for the selected button, Esc for "No",
+// Left, Right and Tab to change the selected button, and mouse clicks.
+func (app *App) Confirm(title, message string, cb func(bool) error) {
+	d := &confirmDialog{title: title, message: message, cb: cb}
+
+	screenWidth, screenHeight := app.Size()
+	textWidth := max(
+		StringWidth(title),
+		StringWidth(message),
+		len(confirmYes)+confirmGap+len(confirmNo),
+	)
+	w := min(textWidth+4, screenWidth)
+	h := 7
+	v := NewView("Confirm", (screenWidth-w)/2, (screenHeight-h)/2, w, h, d.render)
+	v.SetPadding(0, 1, 0, 1)
+	v.SetFillRole(RolePanel)
+	v.SetMouseHandler(d.handleMouse)
+
+	v.Bind(NormalMode, "y", "Yes", "Answer yes", func(v *View) error { return d.answer(v, true) })
+	v.Bind(NormalMode, "n", "No", "Answer no", func(v *View) error { return d.answer(v, false) })
+	v.Bind(NormalMode, "Esc", "Cancel", "Answer no", func(v *View) error { return d.answer(v, false) })
+	v.Bind(NormalMode, "Enter", "Select", "Answer with the selected button", func(v *View) error { return d.answer(v, d.yes) })
+	v.Bind(NormalMode, "Left", "Previous", "Select the other button", d.toggle)
+	v.Bind(NormalMode, "Right", "Next", "Select the other button", d.toggle)
+	v.Bind(NormalMode, "Tab", "Next", "Select the other button", d.toggle)
+	v.Bind(NormalMode, "S-Tab", "Previous", "Select the other button", d.toggle)
+
+	app.PushModal(v)
+}
+
+// buttonsX returns the column the buttons start at, centering them in v.
+func (d *confirmDialog) buttonsX(v *View) int {
+	return max((v.InnerWidth()-len(confirmYes)-confirmGap-len(confirmNo))/2, 0)
+}
+
+func (d *confirmDialog) render(v *View) {
+	theme := v.Theme()
+	style := theme.Style(RolePanel)
+	selected := MergeStyles(style, theme.Style(RoleSelection))
+
+	v.SetTextContent(0, 0, d.title, MergeStyles(style, theme.Style(RoleTitle)))
+	v.SetTextContent(0, 2, d.message, style)
+
+	yesStyle, noStyle := style, selected
+	if d.yes {
+		yesStyle, noStyle = selected, style
+	}
+	x := d.buttonsX(v)
+	v.SetTextContent(x, 4, confirmYes, yesStyle)
+	v.SetTextContent(x+len(confirmYes)+confirmGap, 4, confirmNo, noStyle)
+}
+
+func (d *confirmDialog) toggle(v *View) error {
+	d.yes = !d.yes
+	return nil
+}
+
+func (d *confirmDialog) answer(v *View, yes bool) error {
+	v.app().PopModal()
+	return d.cb(yes)
+}
+
+func (d *confirmDialog) handleMouse(v *View, ev MouseEvent) error {
+	if ev.Action != MousePress || ev.Y != 4 {
+		return nil
+	}
+	x := d.buttonsX(v)
+	noX := x + len(confirmYes) + confirmGap
+	switch {
+	case ev.X >= x && ev.X < x+len(confirmYes):
+		return d.answer(v, true)
+	case ev.X >= noX && ev.X < noX+len(confirmNo):
+		return d.answer(v, false)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package gotuit
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// Events travel along the focus path, from the top level view, or the topmost
+// modal, down to the focused view and back up, like events in a web page:
+//
+//   - Capture: App keybinds are checked first, then the capture keybinds of each
+//     view above the focused view, top down.
+//   - Target: the focused view gets the event.
+//   - Bubble: an event the focused view doesn't handle goes on to its parent, then
+//     its parent's parent, up to the top level view.
+//
+// Tab and Shift+Tab which make it through unhandled move focus, see
+// App.FocusNext.
+//
+// At each view, the event is offered to the view's component, then to its text
+// input while in InputMode, then to its keybinds. The first one to handle it
+// stops the event there. A keybind callback returning Propagate lets the event
+// carry on as if the keybind hadn't matched.
+
+// Propagate can be returned by a keybind callback to pass the key on instead of
+// handling it. It isn't shown in the status line.
+var Propagate = errors.New("Propagate event")
+
+// BindCapture binds key, a single chord, to cb while the view is in mode, like
+// Bind. Capture keybinds also match while focus is on one of the view's
+// descendants, before the descendant sees the key. BindCapture panics if key
+// can't be parsed or is a sequence.
+func (v *View) BindCapture(mode Mode, key string, name, description string, cb func(*View) error) {
+	keys := mustParseKeys(key)
+	if len(keys) != 1 {
+		panic(fmt.Sprintf("gotuit: capture keybind '%s' must be a single chord", key))
+	}
+	v.BindKeys(mode, keys, name, description, cb)
+	v.Keybinds[len(v.Keybinds)-1].capture = true
+}
+
+// focusPath returns the views from the top level view, or the topmost modal,
+// down to the focused view.
+func (app *App) focusPath() []*View {
+	path := []*View{}
+	for v := app.focusedLeaf(); v != nil; v = v.Parent {
+		path = append([]*View{v}, path...)
+	}
+	return path
+}
+
+// dispatchKey runs the capture, target and bubble phases for a key event, after
+// the App keybinds passed on it.
+func (app *App) dispatchKey(ev *tcell.EventKey) {
+	path := app.focusPath()
+	if len(path) == 0 {
+		return
+	}
+
+	key := NewKeyChord(ev)
+	for _, v := range path[:len(path)-1] {
+		if v.capture(key) {
+			return
+		}
+	}
+	if !app.bubble(path, ev) {
+		app.handleFocusKey(path, key)
+	}
+}
+
+// bubble offers ev to the focused view, then each of its ancestors in turn,
+// until one of them handles it. It reports whether one did.
+func (app *App) bubble(path []*View, ev tcell.Event) bool {
+	count := 0
+	for i := len(path) - 1; i >= 0; i-- {
+		handled, rest := path[i].handleEvent(ev, count)
+		if handled {
+			return true
+		}
+		count = rest
+	}
+	return false
+}
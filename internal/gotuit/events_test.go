@@ -0,0 +1,145 @@
+package gotuit
+
+import (
+	"slices"
+	"strconv"
+	"testing"
+)
+
+// newDispatchTree returns an app with a "Parent" view holding a focused "Child",
+// along with the calls recorded by the keybinds made with record.
+func newDispatchTree(t *testing.T) (app *App, parent, child *View, calls *[]string) {
+	app, _ = newFakeClockApp(t)
+	parent = newView("Parent")
+	child = newView("Child")
+	parent.AddChild(child)
+	app.AddView(parent)
+	if err := app.Focus("Child"); err != nil {
+		t.Fatal(err)
+	}
+	calls = &[]string{}
+	return app, parent, child, calls
+}
+
+// record returns a keybind callback which records name along with the count
+// passed to it and returns err.
+func record(calls *[]string, name string, err error) func(*View) error {
+	return func(v *View) error {
+		call := name
+		if v.HasCount() {
+			call += " " + strconv.Itoa(v.Count())
+		}
+		*calls = append(*calls, call)
+		return err
+	}
+}
+
+func checkCalls(t *testing.T, calls *[]string, want ...string) {
+	t.Helper()
+	if !slices.Equal(*calls, want) {
+		t.Fatalf("Got calls %q, want %q", *calls, want)
+	}
+	*calls = (*calls)[:0]
+}
+
+func TestDispatchOrder(t *testing.T) {
+	app, parent, child, calls := newDispatchTree(t)
+	app.Bind("C-x", "App", "", func(*App) error {
+		*calls = append(*calls, "app")
+		return Propagate
+	})
+	parent.BindCapture(NormalMode, "C-x", "Capture", "", record(calls, "capture", nil))
+	parent.Bind(NormalMode, "y", "Parent", "", record(calls, "parent", nil))
+	child.Bind(NormalMode, "y", "Child", "", record(calls, "child", nil))
+	child.Bind(NormalMode, "C-x", "Child", "", record(calls, "child", nil))
+
+	press(app, "C-x")
+	checkCalls(t, calls, "app", "capture")
+	press(app, "y")
+	checkCalls(t, calls, "child")
+}
+
+func TestBubbleToAncestor(t *testing.T) {
+	app, parent, _, calls := newDispatchTree(t)
+	parent.Bind(NormalMode, "z", "Parent", "", record(calls, "parent", nil))
+
+	press(app, "z")
+	checkCalls(t, calls, "parent")
+}
+
+func TestPropagateStops(t *testing.T) {
+	app, parent, child, calls := newDispatchTree(t)
+	parent.Bind(NormalMode, "y", "Parent", "", record(calls, "parent", nil))
+	child.Bind(NormalMode, "y", "Child", "", record(calls, "child", Propagate))
+	parent.Bind(NormalMode, "w", "Parent", "", record(calls, "parent", nil))
+	child.Bind(NormalMode, "w", "Child", "", record(calls, "child", nil))
+
+	press(app, "y")
+	checkCalls(t, calls, "child", "parent")
+	press(app, "w")
+	checkCalls(t, calls, "child")
+}
+
+func TestCountBubbles(t *testing.T) {
+	app, parent, child, calls := newDispatchTree(t)
+	parent.Bind(NormalMode, "z", "Parent", "", record(calls, "parent", nil))
+	parent.Bind(NormalMode, "y", "Parent", "", record(calls, "parent", nil))
+	child.Bind(NormalMode, "y", "Child", "", record(calls, "child", Propagate))
+
+	press(app, "3 z")
+	checkCalls(t, calls, "parent 3")
+	press(app, "4 y")
+	checkCalls(t, calls, "child 4", "parent 4")
+	press(app, "z")
+	checkCalls(t, calls, "parent")
+}
+
+func TestCountClamped(t *testing.T) {
+	app, _, child, calls := newDispatchTree(t)
+	child.Bind(NormalMode, "z", "Child", "", record(calls, "child", nil))
+
+	press(app, "9 9 9 9 9 9 9 9 9 9 9 9 9 9 9 9 9 9 9 9 z")
+	checkCalls(t, calls, "child "+strconv.Itoa(MaxCount))
+}
+
+func TestPendingSequence(t *testing.T) {
+	app, parent, child, calls := newDispatchTree(t)
+	child.Bind(NormalMode, "g g", "Child", "", record(calls, "child gg", nil))
+	child.Bind(NormalMode, "g", "Child", "", record(calls, "child g", nil))
+	parent.Bind(NormalMode, "g", "Parent", "", record(calls, "parent g", nil))
+
+	press(app, "2 g")
+	if got := child.PendingKeys(); got != "2g" {
+		t.Fatalf("Got pending keys '%s' after '2 g'", got)
+	}
+	checkCalls(t, calls)
+	press(app, "g")
+	checkCalls(t, calls, "child gg 2")
+}
+
+func TestPendingSequenceTimeout(t *testing.T) {
+	app, _, child, calls := newDispatchTree(t)
+	clock := app.clock.(*fakeClock)
+	child.Bind(NormalMode, "g g", "Child", "", record(calls, "child gg", nil))
+	child.Bind(NormalMode, "g", "Child", "", record(calls, "child g", nil))
+
+	press(app, "g")
+	clock.Advance(SequenceTimeout)
+	drain(t, app)
+	checkCalls(t, calls, "child g")
+	if got := child.PendingKeys(); got != "" {
+		t.Fatalf("Got pending keys '%s' after the timeout", got)
+	}
+}
+
+func TestPendingSequenceSwallowsKeys(t *testing.T) {
+	app, parent, child, calls := newDispatchTree(t)
+	child.Bind(NormalMode, "g g", "Child", "", record(calls, "child gg", nil))
+	parent.Bind(NormalMode, "x", "Parent", "", record(calls, "parent", nil))
+
+	// A pending sequence on the child swallows keys which don't complete it.
+	press(app, "g x")
+	checkCalls(t, calls)
+	press(app, "x")
+	checkCalls(t, calls, "parent")
+}
@@ -0,0 +1,277 @@
+package gotuit
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// Focus is a single path through the view tree, from a top level view down to
+// the focused view, which receives key events. The views below each open modal
+// keep their own focus, so closing a modal gives focus back to whatever had it
+// before.
+
+// Focus gives focus to the view at target. target is either a path of view
+// names separated by '/' starting from a top level view or open modal, like
+// "Todo List/Test Child", or the name of any view, which is looked up in tree
+// order.
+func (app *App) Focus(target string) error {
+	v := app.findView(target)
+	if v == nil {
+		return fmt.Errorf("View with name '%s' does not exist", target)
+	}
+	return app.setFocus(v)
+}
+
+// Focus gives focus to the view at target, which is looked up like App.Focus
+// but only among the view and its descendants.
+func (v *View) Focus(target string) error {
+	found := v.findView(target)
+	if found == nil {
+		return fmt.Errorf("View with name '%s' does not exist", target)
+	}
+	app := found.app()
+	if app == nil {
+		return fmt.Errorf("View '%s' hasn't been added to an App", found.Name)
+	}
+	return app.setFocus(found)
+}
+
+// IsFocused reports whether the view receives key events.
+func (v *View) IsFocused() bool {
+	app := v.app()
+	return app != nil && app.focusedLeaf() == v
+}
+
+// ContainsFocus reports whether the view or one of its descendants has focus.
+func (v *View) ContainsFocus() bool {
+	app := v.app()
+	if app == nil {
+		return false
+	}
+	for f := app.focusedLeaf(); f != nil; f = f.Parent {
+		if f == v {
+			return true
+		}
+	}
+	return false
+}
+
+// GetFocusedView returns the focused view if it is the view itself or one of its
+// descendants.
+func (v *View) GetFocusedView() (*View, error) {
+	if !v.ContainsFocus() {
+		return nil, fmt.Errorf("No view within '%s' has focus", v.Name)
+	}
+	return v.app().focusedLeaf(), nil
+}
+
+// FocusedView returns the name of the focused view if it is the view itself or
+// one of its descendants, or an empty string otherwise.
+func (v *View) FocusedView() string {
+	focused, err := v.GetFocusedView()
+	if err != nil {
+		return ""
+	}
+	return focused.Name
+}
+
+// OnFocus sets cb to be called whenever the view gains focus.
+func (v *View) OnFocus(cb func(*View)) {
+	v.onFocus = cb
+}
+
+// OnBlur sets cb to be called whenever the view loses focus.
+func (v *View) OnBlur(cb func(*View)) {
+	v.onBlur = cb
+}
+
+// FocusNext gives focus to the next focusable, visible view in tree order,
+// wrapping around after the last one. While a modal is open, only the modal and
+// its descendants take part.
+func (app *App) FocusNext() error {
+	return app.cycleFocus(1)
+}
+
+// FocusPrevious is like FocusNext, going backwards.
+func (app *App) FocusPrevious() error {
+	return app.cycleFocus(-1)
+}
+
+func (app *App) cycleFocus(delta int) error {
+	views := []*View{}
+	for _, root := range app.layerRoots() {
+		views = root.appendFocusable(views)
+	}
+	if len(views) == 0 {
+		return nil
+	}
+
+	idx := -1
+	for i, v := range views {
+		if v == app.focusedLeaf() {
+			idx = i
+		}
+	}
+	if idx < 0 && delta < 0 {
+		idx = 0
+	}
+	return app.setFocus(views[(idx+delta+len(views))%len(views)])
+}
+
+// appendFocusable appends the view and its descendants which can take focus to
+// views, in tree order. Hidden views and their descendants are skipped.
+func (v *View) appendFocusable(views []*View) []*View {
+	if !v.visible {
+		return views
+	}
+	if v.focusable {
+		views = append(views, v)
+	}
+	for _, child := range v.Children {
+		views = child.appendFocusable(views)
+	}
+	return views
+}
+
+// layerRoots returns the top level views of the layer taking key events: the
+// topmost modal if one is open, otherwise the views added with App.AddView.
+func (app *App) layerRoots() []*View {
+	if top := app.TopModal(); top != nil {
+		return []*View{top}
+	}
+	return app.views
+}
+
+// focusKeys are the keys which move focus when no view handles them.
+var focusKeys = map[KeyChord]func(*App) error{
+	{Key: tcell.KeyTab}:     (*App).FocusNext,
+	{Key: tcell.KeyBacktab}: (*App).FocusPrevious,
+}
+
+// handleFocusKey moves focus for Tab and Shift+Tab, unless a view on path is
+// taking text input.
+func (app *App) handleFocusKey(path []*View, key KeyChord) {
+	move, ok := focusKeys[key]
+	if !ok {
+		return
+	}
+	for _, v := range path {
+		if v.Mode == InputMode {
+			return
+		}
+	}
+	app.reportError(move(app))
+}
+
+// setFocus gives focus to v in the layer v belongs to.
+func (app *App) setFocus(v *View) error {
+	if !v.focusable {
+		return fmt.Errorf("View '%s' can't take focus", v.Name)
+	}
+	root := v
+	for root.Parent != nil {
+		root = root.Parent
+	}
+
+	layer := 0
+	for i, modal := range app.modals {
+		if modal == root {
+			layer = i + 1
+		}
+	}
+	invalidatePath(app.focusedLeaf())
+	app.focus[layer] = v
+	invalidatePath(v)
+	app.inputState.Changed()
+	slog.Debug("Switching focus", "view", v.Name)
+	app.syncFocus()
+	return nil
+}
+
+// focusedLeaf returns the view receiving key events, or nil if there is none.
+func (app *App) focusedLeaf() *View {
+	return app.focus[len(app.focus)-1]
+}
+
+// findView returns the view at path, looked up as described for App.Focus, or
+// nil if there is none. Open modals are searched before other views, the topmost
+// first.
+func (app *App) findView(path string) *View {
+	roots := []*View{}
+	for i := len(app.modals) - 1; i >= 0; i-- {
+		roots = append(roots, app.modals[i])
+	}
+	roots = append(roots, app.views...)
+
+	for _, root := range roots {
+		if v := root.findView(path); v != nil {
+			return v
+		}
+	}
+	return nil
+}
+
+// findView returns the view at path relative to the view, starting with its own
+// name, or the first view in tree order named path if it holds a single name.
+func (v *View) findView(path string) *View {
+	names := strings.Split(path, "/")
+	if len(names) == 1 {
+		return v.findNamed(path)
+	}
+
+	if names[0] != v.Name {
+		return nil
+	}
+	found := v
+	for _, name := range names[1:] {
+		child, ok := found.GetView(name)
+		if !ok {
+			return nil
+		}
+		found = child
+	}
+	return found
+}
+
+func (v *View) findNamed(name string) *View {
+	if v.Name == name {
+		return v
+	}
+	for _, child := range v.Children {
+		if found := child.findNamed(name); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// syncFocus calls the focus and blur hooks of the views losing and gaining focus
+// since the last call.
+func (app *App) syncFocus() {
+	leaf := app.focusedLeaf()
+	if leaf == app.notified {
+		return
+	}
+	if old := app.notified; old != nil {
+		old.Invalidate()
+		if old.component != nil {
+			old.component.Blur(old)
+		}
+		if old.onBlur != nil {
+			old.onBlur(old)
+		}
+	}
+	app.notified = leaf
+	if leaf != nil {
+		leaf.Invalidate()
+		if leaf.component != nil {
+			leaf.component.Focus(leaf)
+		}
+		if leaf.onFocus != nil {
+			leaf.onFocus(leaf)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package gotuit
+
+import (
+	"slices"
+	"testing"
+)
+
+func newView(name string) *View {
+	return NewView(name, 0, 0, 10, 5, func(*View) {})
+}
+
+// newFocusTree adds the views
+//
+//	A
+//	├── A1
+//	└── A2 (hidden)
+//	    └── A2a
+//	B (not focusable)
+//	C
+//
+// to a test app. A1 is added after A, so it only gets its App from its parent.
+func newFocusTree(t *testing.T) (*App, map[string]*View) {
+	app, _ := newTestApp(t, 80, 24)
+	views := map[string]*View{}
+	for _, name := range []string{"A", "A1", "A2", "A2a", "B", "C"} {
+		views[name] = newView(name)
+	}
+	views["A2"].AddChild(views["A2a"])
+	views["A"].AddChild(views["A2"])
+	views["A2"].Hide()
+	views["B"].SetFocusable(false)
+	app.AddView(views["A"])
+	app.AddView(views["B"])
+	app.AddView(views["C"])
+	views["A"].AddChild(views["A1"])
+	return app, views
+}
+
+func focusedName(app *App) string {
+	if leaf := app.focusedLeaf(); leaf != nil {
+		return leaf.Name
+	}
+	return ""
+}
+
+func TestFocusNextTreeOrder(t *testing.T) {
+	app, _ := newFocusTree(t)
+	want := []string{"A", "A1", "C", "A"}
+	for _, name := range want {
+		if err := app.FocusNext(); err != nil {
+			t.Fatal(err)
+		}
+		if got := focusedName(app); got != name {
+			t.Fatalf("FocusNext focused '%s', want '%s'", got, name)
+		}
+	}
+}
+
+func TestFocusPreviousWraps(t *testing.T) {
+	app, _ := newFocusTree(t)
+	if err := app.Focus("A"); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"C", "A1", "A"} {
+		if err := app.FocusPrevious(); err != nil {
+			t.Fatal(err)
+		}
+		if got := focusedName(app); got != name {
+			t.Fatalf("FocusPrevious focused '%s', want '%s'", got, name)
+		}
+	}
+}
+
+func TestTabThroughChild(t *testing.T) {
+	app, views := newFocusTree(t)
+	// A keybind reaching the App through a view added with AddChild.
+	views["A1"].Bind(NormalMode, "C-t", "Next", "Focus the next view", func(v *View) error {
+		return v.App.FocusNext()
+	})
+	if err := app.Focus("A"); err != nil {
+		t.Fatal(err)
+	}
+
+	press(app, "Tab")
+	if got := focusedName(app); got != "A1" {
+		t.Fatalf("Tab focused '%s', want 'A1'", got)
+	}
+	press(app, "Tab")
+	if got := focusedName(app); got != "C" {
+		t.Fatalf("Tab focused '%s', want 'C'", got)
+	}
+	press(app, "S-Tab C-t")
+	if got := focusedName(app); got != "C" {
+		t.Fatalf("Shift+Tab then Ctrl+T focused '%s', want 'C'", got)
+	}
+}
+
+func TestTabIgnoredInInputMode(t *testing.T) {
+	app, views := newFocusTree(t)
+	if err := app.Focus("A1"); err != nil {
+		t.Fatal(err)
+	}
+	views["A"].Mode = InputMode
+	press(app, "Tab")
+	if got := focusedName(app); got != "A1" {
+		t.Fatalf("Tab focused '%s' while in InputMode, want 'A1'", got)
+	}
+}
+
+func TestFocusHiddenOrUnfocusable(t *testing.T) {
+	app, _ := newFocusTree(t)
+	if err := app.Focus("B"); err == nil {
+		t.Error("Focusing a view which isn't focusable succeeded")
+	}
+	if err := app.Focus("Missing"); err == nil {
+		t.Error("Focusing a missing view succeeded")
+	}
+}
+
+func TestPopModalRestoresFocus(t *testing.T) {
+	app, _ := newFocusTree(t)
+	if err := app.Focus("A/A1"); err != nil {
+		t.Fatal(err)
+	}
+
+	modal := newView("Modal")
+	modal.AddChild(newView("M1"))
+	app.PushModal(modal)
+	if got := focusedName(app); got != "Modal" {
+		t.Fatalf("PushModal focused '%s', want 'Modal'", got)
+	}
+	for _, name := range []string{"M1", "Modal"} {
+		press(app, "Tab")
+		if got := focusedName(app); got != name {
+			t.Fatalf("Tab in a modal focused '%s', want '%s'", got, name)
+		}
+	}
+
+	app.PopModal()
+	if got := focusedName(app); got != "A1" {
+		t.Fatalf("PopModal gave focus to '%s', want 'A1'", got)
+	}
+}
+
+func TestFocusHookOrder(t *testing.T) {
+	app, _ := newTestApp(t, 80, 24)
+	events := []string{}
+	for _, name := range []string{"A", "B"} {
+		v := NewComponentView(name, 0, 0, 10, 5, &recordingComponent{events: &events})
+		v.OnFocus(func(v *View) {
+			events = append(events, "focus "+v.Name)
+		})
+		v.OnBlur(func(v *View) {
+			events = append(events, "blur "+v.Name)
+		})
+		app.AddView(v)
+	}
+
+	if err := app.Focus("A"); err != nil {
+		t.Fatal(err)
+	}
+	if err := app.Focus("A"); err != nil {
+		t.Fatal(err)
+	}
+	if err := app.Focus("B"); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"component focus A", "focus A",
+		"component blur A", "blur A", "component focus B", "focus B",
+	}
+	if !slices.Equal(events, want) {
+		t.Fatalf("Got hooks %q, want %q", events, want)
+	}
+}
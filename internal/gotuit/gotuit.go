@@ -5,4 +5,5 @@ type Mode int
 const (
 	NormalMode Mode = iota
 	InputMode
+	VisualMode
 )
@@ -0,0 +1,219 @@
+package gotuit
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// KeyChord is a single key press with its modifiers. Printable keys have Key set
+// to tcell.KeyRune and the character in Rune, every other key leaves Rune empty.
+//
+// Chords are always normalized so that the chord parsed from "C-k" is equal to
+// the chord created from the event tcell sends for Ctrl+K.
+type KeyChord struct {
+	Key  tcell.Key
+	Rune rune
+	Mod  tcell.ModMask
+}
+
+// NewKeyChord returns the chord for a key event.
+func NewKeyChord(ev *tcell.EventKey) KeyChord {
+	kc := KeyChord{Key: ev.Key(), Mod: ev.Modifiers()}
+	if kc.Key == tcell.KeyRune {
+		kc.Rune = ev.Rune()
+	}
+	return kc.normalize()
+}
+
+// RuneChord returns the chord for typing r without modifiers.
+func RuneChord(r rune) KeyChord {
+	return KeyChord{Key: tcell.KeyRune, Rune: r}.normalize()
+}
+
+// isTypeable reports whether key is a control code that has a dedicated key on
+// the keyboard, and so is reported without ModCtrl.
+func isTypeable(key tcell.Key) bool {
+	switch key {
+	case tcell.KeyTab, tcell.KeyEnter, tcell.KeyEsc, tcell.KeyBackspace:
+		return true
+	}
+	return false
+}
+
+func (kc KeyChord) normalize() KeyChord {
+	if kc.Key == tcell.KeyRune {
+		if kc.Mod&tcell.ModShift != 0 {
+			kc.Rune = unicode.ToUpper(kc.Rune)
+			kc.Mod &^= tcell.ModShift
+		}
+		if kc.Mod&tcell.ModCtrl != 0 {
+			lower := unicode.ToLower(kc.Rune)
+			switch {
+			case lower >= 'a' && lower <= 'z':
+				kc.Key = tcell.KeyCtrlA + tcell.Key(lower-'a')
+				kc.Rune = 0
+			case lower == ' ':
+				kc.Key = tcell.KeyCtrlSpace
+				kc.Rune = 0
+			}
+		}
+	}
+
+	switch kc.Key {
+	case tcell.KeyBackspace2:
+		kc.Key = tcell.KeyBackspace
+	case tcell.KeyTab:
+		if kc.Mod&tcell.ModShift != 0 {
+			kc.Key = tcell.KeyBacktab
+		}
+	}
+	if kc.Key == tcell.KeyBacktab {
+		kc.Mod &^= tcell.ModShift
+	}
+
+	if kc.Key < ' ' {
+		if isTypeable(kc.Key) {
+			kc.Mod &^= tcell.ModCtrl
+		} else {
+			kc.Mod |= tcell.ModCtrl
+		}
+	}
+
+	return kc
+}
+
+// isPlainRune reports whether the chord types a character, as opposed to being
+// a shortcut.
+func (kc KeyChord) isPlainRune() bool {
+	return kc.Key == tcell.KeyRune && kc.Mod == tcell.ModNone
+}
+
+// String returns the chord in the format accepted by ParseKeyChord.
+func (kc KeyChord) String() string {
+	var prefix string
+	if kc.Mod&tcell.ModCtrl != 0 {
+		prefix += "C-"
+	}
+	if kc.Mod&tcell.ModAlt != 0 || kc.Mod&tcell.ModMeta != 0 {
+		prefix += "M-"
+	}
+	if kc.Mod&tcell.ModShift != 0 {
+		prefix += "S-"
+	}
+
+	switch {
+	case kc.Key == tcell.KeyRune && kc.Rune == ' ':
+		return prefix + "Space"
+	case kc.Key == tcell.KeyRune:
+		return prefix + string(kc.Rune)
+	case kc.Key == tcell.KeyBacktab:
+		return prefix + "S-Tab"
+	case kc.Key == tcell.KeyCtrlSpace:
+		return prefix + "Space"
+	case kc.Key >= tcell.KeyCtrlA && kc.Key <= tcell.KeyCtrlZ && !isTypeable(kc.Key):
+		return prefix + string(rune('a'+kc.Key-tcell.KeyCtrlA))
+	}
+
+	name, ok := tcell.KeyNames[kc.Key]
+	if !ok {
+		return prefix + fmt.Sprintf("Key[%d]", kc.Key)
+	}
+	return prefix + strings.TrimPrefix(name, "Ctrl-")
+}
+
+var keyAliases = map[string]KeyChord{
+	"space":    {Key: tcell.KeyRune, Rune: ' '},
+	"escape":   {Key: tcell.KeyEsc},
+	"return":   {Key: tcell.KeyEnter},
+	"del":      {Key: tcell.KeyDelete},
+	"pageup":   {Key: tcell.KeyPgUp},
+	"pagedown": {Key: tcell.KeyPgDn},
+}
+
+func lookupKeyName(name string) (KeyChord, bool) {
+	name = strings.ToLower(name)
+	if kc, ok := keyAliases[name]; ok {
+		return kc, true
+	}
+	for key, keyName := range tcell.KeyNames {
+		if strings.ToLower(keyName) == name {
+			return KeyChord{Key: key}, true
+		}
+	}
+	return KeyChord{}, false
+}
+
+// ParseKeyChord parses a single chord. A chord is a character or key name, like
+// "j", "Enter" or "F1", optionally preceded by modifiers: "C-" for Ctrl, "M-" or
+// "A-" for Alt and "S-" for Shift. For example "C-k", "M-Enter" or "S-Tab".
+func ParseKeyChord(s string) (KeyChord, error) {
+	base := s
+	var mod tcell.ModMask
+	for len(base) > 2 && base[1] == '-' {
+		switch base[0] {
+		case 'C':
+			mod |= tcell.ModCtrl
+		case 'M', 'A':
+			mod |= tcell.ModAlt
+		case 'S':
+			mod |= tcell.ModShift
+		default:
+			return KeyChord{}, fmt.Errorf("Unknown modifier '%c' in key '%s'", base[0], s)
+		}
+		base = base[2:]
+	}
+
+	var kc KeyChord
+	if utf8.RuneCountInString(base) == 1 {
+		r, _ := utf8.DecodeRuneInString(base)
+		kc = KeyChord{Key: tcell.KeyRune, Rune: r}
+	} else {
+		named, ok := lookupKeyName(base)
+		if !ok {
+			return KeyChord{}, fmt.Errorf("Unknown key '%s'", s)
+		}
+		kc = named
+	}
+	kc.Mod |= mod
+
+	return kc.normalize(), nil
+}
+
+// ParseKeys parses a space separated sequence of chords, like "g g" or "C-x C-s".
+func ParseKeys(s string) ([]KeyChord, error) {
+	fields := strings.Fields(s)
+	if len(fields) < 1 {
+		return nil, fmt.Errorf("Empty key sequence")
+	}
+
+	keys := make([]KeyChord, 0, len(fields))
+	for _, field := range fields {
+		kc, err := ParseKeyChord(field)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, kc)
+	}
+	return keys, nil
+}
+
+func mustParseKeys(s string) []KeyChord {
+	keys, err := ParseKeys(s)
+	if err != nil {
+		panic(fmt.Sprintf("gotuit: invalid keybind '%s': %s", s, err))
+	}
+	return keys
+}
+
+// KeysString formats a key sequence in the format accepted by ParseKeys.
+func KeysString(keys []KeyChord) string {
+	parts := make([]string, 0, len(keys))
+	for _, kc := range keys {
+		parts = append(parts, kc.String())
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,92 @@
+package gotuit
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestParseKeyChord(t *testing.T) {
+	for _, tc := range []struct {
+		keys string
+		want KeyChord
+	}{
+		{"j", KeyChord{Key: tcell.KeyRune, Rune: 'j'}},
+		{"J", KeyChord{Key: tcell.KeyRune, Rune: 'J'}},
+		{"S-j", KeyChord{Key: tcell.KeyRune, Rune: 'J'}},
+		{"S-J", KeyChord{Key: tcell.KeyRune, Rune: 'J'}},
+		{"S-ö", KeyChord{Key: tcell.KeyRune, Rune: 'Ö'}},
+		{"M-j", KeyChord{Key: tcell.KeyRune, Rune: 'j', Mod: tcell.ModAlt}},
+		{"A-j", KeyChord{Key: tcell.KeyRune, Rune: 'j', Mod: tcell.ModAlt}},
+		{"C-k", KeyChord{Key: tcell.KeyCtrlK, Mod: tcell.ModCtrl}},
+		{"C-K", KeyChord{Key: tcell.KeyCtrlK, Mod: tcell.ModCtrl}},
+		{"C-S-k", KeyChord{Key: tcell.KeyCtrlK, Mod: tcell.ModCtrl}},
+		{"C-M-k", KeyChord{Key: tcell.KeyCtrlK, Mod: tcell.ModCtrl | tcell.ModAlt}},
+		{"C-h", KeyChord{Key: tcell.KeyBackspace}},
+		{"C-i", KeyChord{Key: tcell.KeyTab}},
+		{"C-m", KeyChord{Key: tcell.KeyEnter}},
+		{"C-Space", KeyChord{Key: tcell.KeyCtrlSpace, Mod: tcell.ModCtrl}},
+		{"Space", KeyChord{Key: tcell.KeyRune, Rune: ' '}},
+		{"Backspace", KeyChord{Key: tcell.KeyBackspace}},
+		{"Backspace2", KeyChord{Key: tcell.KeyBackspace}},
+		{"Tab", KeyChord{Key: tcell.KeyTab}},
+		{"S-Tab", KeyChord{Key: tcell.KeyBacktab}},
+		{"Backtab", KeyChord{Key: tcell.KeyBacktab}},
+		{"Enter", KeyChord{Key: tcell.KeyEnter}},
+		{"return", KeyChord{Key: tcell.KeyEnter}},
+		{"M-Enter", KeyChord{Key: tcell.KeyEnter, Mod: tcell.ModAlt}},
+		{"Escape", KeyChord{Key: tcell.KeyEsc}},
+		{"pagedown", KeyChord{Key: tcell.KeyPgDn}},
+		{"F1", KeyChord{Key: tcell.KeyF1}},
+		{"S-Up", KeyChord{Key: tcell.KeyUp, Mod: tcell.ModShift}},
+	} {
+		got, err := ParseKeyChord(tc.keys)
+		if err != nil {
+			t.Fatalf("'%s': %s", tc.keys, err)
+		}
+		if got != tc.want {
+			t.Errorf("Got %#v for '%s', want %#v", got, tc.keys, tc.want)
+		}
+		if round, err := ParseKeyChord(got.String()); err != nil || round != got {
+			t.Errorf("'%s' formats as '%s', which doesn't parse back to it", tc.keys, got)
+		}
+	}
+}
+
+func TestParseKeyChordErrors(t *testing.T) {
+	for _, keys := range []string{"X-a", "Foo", "C-Foo", "jj"} {
+		if _, err := ParseKeyChord(keys); err == nil {
+			t.Errorf("Parsed '%s' without an error", keys)
+		}
+	}
+	if _, err := ParseKeys(" "); err == nil {
+		t.Error("Parsed an empty sequence without an error")
+	}
+}
+
+func TestNewKeyChord(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		ev   *tcell.EventKey
+		keys string
+	}{
+		{"Ctrl+K", tcell.NewEventKey(tcell.KeyCtrlK, 0, tcell.ModCtrl), "C-k"},
+		{"Ctrl+H", tcell.NewEventKey(tcell.KeyCtrlH, 0, tcell.ModCtrl), "Backspace"},
+		{"Backspace", tcell.NewEventKey(tcell.KeyBackspace2, 0, tcell.ModNone), "Backspace"},
+		{"Tab", tcell.NewEventKey(tcell.KeyTab, 0, tcell.ModNone), "Tab"},
+		{"Shift+Tab", tcell.NewEventKey(tcell.KeyBacktab, 0, tcell.ModShift), "S-Tab"},
+		{"Shift+Tab without a backtab key", tcell.NewEventKey(tcell.KeyTab, 0, tcell.ModShift), "S-Tab"},
+		{"Shift+A", tcell.NewEventKey(tcell.KeyRune, 'A', tcell.ModShift), "A"},
+		{"Shift+a", tcell.NewEventKey(tcell.KeyRune, 'a', tcell.ModShift), "S-a"},
+		{"Alt+x", tcell.NewEventKey(tcell.KeyRune, 'x', tcell.ModAlt), "M-x"},
+		{"Ctrl+Space", tcell.NewEventKey(tcell.KeyCtrlSpace, 0, tcell.ModCtrl), "C-Space"},
+	} {
+		want, err := ParseKeyChord(tc.keys)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := NewKeyChord(tc.ev); got != want {
+			t.Errorf("Got %#v for %s, want '%s' %#v", got, tc.name, tc.keys, want)
+		}
+	}
+}
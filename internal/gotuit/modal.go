@@ -0,0 +1,69 @@
+package gotuit
+
+// PushModal shows v above every other view. Until it is popped, v receives all
+// key and mouse events and the views below it are dimmed. Pushing a view which is
+// already open does nothing.
+func (app *App) PushModal(v *View) {
+	if app.isModal(v) {
+		return
+	}
+	v.setApp(app)
+	v.Show()
+	app.modals = append(app.modals, v)
+	app.focus = append(app.focus, v)
+	app.invalidateLayout()
+	app.syncFocus()
+}
+
+// PopModal closes the topmost modal, giving focus back to whatever had it before
+// the modal was pushed. It returns the closed modal, or nil if none is open.
+func (app *App) PopModal() *View {
+	top := app.TopModal()
+	if top == nil {
+		return nil
+	}
+	top.Hide()
+	top.resetPending()
+	app.modals = app.modals[:len(app.modals)-1]
+	app.focus = app.focus[:len(app.focus)-1]
+	app.invalidateLayout()
+	app.syncFocus()
+	return top
+}
+
+// TopModal returns the topmost modal, or nil if none is open.
+func (app *App) TopModal() *View {
+	if len(app.modals) == 0 {
+		return nil
+	}
+	return app.modals[len(app.modals)-1]
+}
+
+func (app *App) isModal(v *View) bool {
+	for _, modal := range app.modals {
+		if modal == v {
+			return true
+		}
+	}
+	return false
+}
+
+// dim dims every cell already drawn to the screen, so modals stand out from the
+// views behind them.
+func (app *App) dim() {
+	w, h := app.screen.Size()
+	for y := range h {
+		for x := range w {
+			mainc, combc, style, _ := app.screen.GetContent(x, y)
+			app.screen.SetContent(x, y, mainc, combc, style.Dim(true))
+		}
+	}
+}
+
+func (app *App) drawModals() {
+	for _, modal := range app.modals {
+		app.dim()
+		modal.render()
+		modal.Draw(app.screen)
+	}
+}
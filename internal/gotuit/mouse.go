@@ -0,0 +1,137 @@
+package gotuit
+
+import (
+	"github.com/gdamore/tcell/v2"
+)
+
+type MouseAction int
+
+const (
+	MousePress MouseAction = iota
+	MouseDrag
+	MouseRelease
+	MouseWheelUp
+	MouseWheelDown
+)
+
+// MouseEvent is a mouse event delivered to a view. X and Y are relative to the
+// view's inner area, so they line up with the coordinates given to
+// View.SetTextContent. They may be negative or past the edge of the view while
+// dragging.
+type MouseEvent struct {
+	X, Y    int
+	Action  MouseAction
+	Buttons tcell.ButtonMask
+	Mod     tcell.ModMask
+}
+
+// SetMouseHandler sets the callback that receives mouse events over the view.
+// Pressing a button over a view focuses it before the handler is called, and
+// the view keeps receiving drag and release events until every button is let go.
+// An error returned by cb is shown in the status line.
+func (v *View) SetMouseHandler(cb func(*View, MouseEvent) error) {
+	v.mouseHandler = cb
+}
+
+// SetFocusable sets whether the view can receive focus. Views are focusable by
+// default.
+func (v *View) SetFocusable(focusable bool) {
+	v.focusable = focusable
+}
+
+func (v *View) contains(x, y int) bool {
+	x1, y1, x2, y2 := v.getClipBounds()
+	return x >= x1 && x <= x2 && y >= y1 && y <= y2
+}
+
+// viewAt returns the deepest visible descendant of v, or v itself, containing the
+// screen position x, y.
+func (v *View) viewAt(x, y int) *View {
+	for i := len(v.Children) - 1; i >= 0; i-- {
+		child := v.Children[i]
+		if child.visible && child.contains(x, y) {
+			return child.viewAt(x, y)
+		}
+	}
+	return v
+}
+
+// viewAt returns the topmost visible view containing the screen position x, y.
+func (app *App) viewAt(x, y int) *View {
+	if top := app.TopModal(); top != nil {
+		if top.contains(x, y) {
+			return top.viewAt(x, y)
+		}
+		return nil
+	}
+	for i := len(app.views) - 1; i >= 0; i-- {
+		v := app.views[i]
+		if v.visible && v.contains(x, y) {
+			return v.viewAt(x, y)
+		}
+	}
+	return nil
+}
+
+func (app *App) handleMouse(ev *tcell.EventMouse) {
+	x, y := ev.Position()
+	buttons := ev.Buttons()
+	wheel := buttons & (tcell.WheelUp | tcell.WheelDown | tcell.WheelLeft | tcell.WheelRight)
+	buttons &^= wheel
+	previous := app.mouseButtons
+	app.mouseButtons = buttons
+
+	if wheel != 0 {
+		target := app.viewAt(x, y)
+		if target == nil {
+			return
+		}
+		action := MouseWheelDown
+		if wheel&tcell.WheelUp != 0 {
+			action = MouseWheelUp
+		}
+		target.dispatchMouse(x, y, action, buttons, ev.Modifiers())
+		return
+	}
+
+	switch {
+	case buttons != tcell.ButtonNone && previous == tcell.ButtonNone:
+		target := app.viewAt(x, y)
+		app.mouseTarget = target
+		if target == nil {
+			return
+		}
+		// Don't pull focus away from a view while it's taking text input.
+		if focused := app.focusedLeaf(); focused != nil && focused != target && focused.Mode == InputMode {
+			app.mouseTarget = nil
+			return
+		}
+		if target.focusable {
+			app.setFocus(target)
+		}
+		target.dispatchMouse(x, y, MousePress, buttons, ev.Modifiers())
+	case buttons != tcell.ButtonNone && app.mouseTarget != nil:
+		app.mouseTarget.dispatchMouse(x, y, MouseDrag, buttons, ev.Modifiers())
+	case buttons == tcell.ButtonNone && previous != tcell.ButtonNone && app.mouseTarget != nil:
+		app.mouseTarget.dispatchMouse(x, y, MouseRelease, previous, ev.Modifiers())
+		app.mouseTarget = nil
+	}
+}
+
+func (v *View) dispatchMouse(x, y int, action MouseAction, buttons tcell.ButtonMask, mod tcell.ModMask) {
+	if v.mouseHandler == nil {
+		return
+	}
+	x1, y1, _, _ := v.getInnerBounds()
+	err := v.mouseHandler(v, MouseEvent{
+		X:       x - x1,
+		Y:       y - y1,
+		Action:  action,
+		Buttons: buttons,
+		Mod:     mod,
+	})
+	app := v.app()
+	invalidatePath(v)
+	app.inputState.Changed()
+	app.reportError(err)
+}
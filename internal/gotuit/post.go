@@ -0,0 +1,80 @@
+package gotuit
+
+import (
+	"time"
+)
+
+// Functions reach the event loop in one of two ways. Post sends each one as its
+// own event, which tcell drops when its event queue is full. Timers and Notify
+// can't afford to lose theirs, so they add them to a queue on the App instead,
+// which the event loop empties after every event it handles.
+
+// funcEvent carries a function to the event loop. With redraw set, every view
+// is redrawn after it unless fn invalidated some itself.
+type funcEvent struct {
+	t      time.Time
+	fn     func(*App)
+	redraw bool
+}
+
+func (ev *funcEvent) When() time.Time {
+	return ev.t
+}
+
+// Post queues fn to run on the goroutine running App.MainLoop, followed by a
+// redraw. Post and App.Notify are the only App methods safe to call from other
+// goroutines, so background work should use Post to touch views or any state
+// they render. If fn invalidates some views, only those are rendered again,
+// otherwise every view is.
+//
+// Post doesn't wait for fn to run. It returns an error if the event queue is
+// full.
+func (app *App) Post(fn func(*App)) error {
+	return app.screen.PostEvent(&funcEvent{t: time.Now(), fn: fn, redraw: true})
+}
+
+// wakeEvent wakes up the event loop to run the functions added with
+// App.enqueue.
+type wakeEvent struct {
+	t time.Time
+}
+
+func (ev *wakeEvent) When() time.Time {
+	return ev.t
+}
+
+// enqueue queues fn to run on the event loop like Post, except fn is never
+// dropped. If the event queue is full, fn runs after one of the events already
+// in it.
+func (app *App) enqueue(fn func(*App)) {
+	app.queueMu.Lock()
+	app.queued = append(app.queued, fn)
+	wake := len(app.queued) == 1
+	app.queueMu.Unlock()
+
+	if wake {
+		app.screen.PostEvent(&wakeEvent{t: time.Now()})
+	}
+}
+
+// runQueued runs the functions added with App.enqueue, oldest first. Unlike
+// functions passed to Post, they only redraw the views they invalidate.
+func (app *App) runQueued() {
+	app.queueMu.Lock()
+	queued := app.queued
+	app.queued = nil
+	app.queueMu.Unlock()
+
+	for _, fn := range queued {
+		fn(app)
+	}
+}
+
+// runFunc runs the function carried by ev.
+func (app *App) runFunc(ev *funcEvent) {
+	invalidations := app.invalidations
+	ev.fn(app)
+	if ev.redraw && app.invalidations == invalidations {
+		app.Invalidate()
+	}
+}
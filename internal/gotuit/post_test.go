@@ -0,0 +1,85 @@
+package gotuit
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// runLoop runs the main loop in its own goroutine until the returned function is
+// called, which quits the loop once everything posted before it has run.
+func runLoop(t *testing.T, app *App) (stop func()) {
+	t.Helper()
+	done := make(chan error)
+	go func() {
+		done <- app.MainLoop()
+	}()
+	return func() {
+		mustPost(app, func(app *App) {
+			app.Quit()
+		})
+		if err := <-done; err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestPostFromManyGoroutines(t *testing.T) {
+	app, _ := newTestApp(t, 80, 24)
+	const goroutines, posts = 20, 100
+	ran := make([]int, goroutines*posts)
+
+	stop := runLoop(t, app)
+	var wg sync.WaitGroup
+	for g := range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range posts {
+				id := g*posts + i
+				mustPost(app, func(*App) {
+					ran[id]++
+				})
+			}
+		}()
+	}
+	wg.Wait()
+	stop()
+
+	for id, count := range ran {
+		if count != 1 {
+			t.Errorf("Posted function %d ran %d times, want 1", id, count)
+		}
+	}
+}
+
+func TestNotifyFromManyGoroutines(t *testing.T) {
+	app, _ := newTestApp(t, 80, 24)
+	const goroutines, notes = 10, 20
+
+	stop := runLoop(t, app)
+	var wg sync.WaitGroup
+	for g := range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range notes {
+				app.Notify(SeverityInfo, fmt.Sprintf("message %d.%d", g, i))
+			}
+		}()
+	}
+	wg.Wait()
+	stop()
+
+	history := app.StatusHistory()
+	if len(history) != statusHistorySize {
+		t.Fatalf("Got %d status messages, want %d", len(history), statusHistorySize)
+	}
+	seen := map[string]bool{}
+	for _, msg := range history {
+		if msg.Count != 1 || seen[msg.Text] {
+			t.Errorf("Message '%s' recorded more than once", msg.Text)
+		}
+		seen[msg.Text] = true
+	}
+}
@@ -0,0 +1,126 @@
+package gotuit
+
+// Views are only rendered again once they have been invalidated. A key event
+// invalidates the views along the focus path, which handle it, and a mouse event
+// the view it reaches and that view's ancestors. Views showing state changed by
+// those events, somewhere other than on the path, watch a State which the
+// callbacks mark changed. Functions run by timers invalidate what they change
+// themselves, so a timer which finds nothing to do, like the timeout of a key
+// sequence that was already finished, redraws nothing. Resizing, themes, modals
+// and showing or hiding views invalidate everything.
+
+// State is state which several views render from, like an application's model.
+// Views watching it with View.Watch are invalidated whenever it is marked changed.
+// Like views, a State must only be used on the goroutine running App.MainLoop.
+type State struct {
+	views []*View
+}
+
+// Changed invalidates every view watching the state.
+func (s *State) Changed() {
+	for _, v := range s.views {
+		v.Invalidate()
+	}
+}
+
+// Watch invalidates the view whenever s is marked changed.
+func (v *View) Watch(s *State) {
+	s.views = append(s.views, v)
+}
+
+// InputState is marked changed after every key event, mouse event reaching a
+// view, and change of focus, for views showing things like the mode or pending
+// keys of the focused view.
+func (app *App) InputState() *State {
+	return &app.inputState
+}
+
+// StatusState is marked changed whenever the status message changes, for views
+// showing it.
+func (app *App) StatusState() *State {
+	return &app.statusState
+}
+
+// Invalidate marks the view to be rendered again on the next frame.
+func (v *View) Invalidate() {
+	v.dirty = true
+	if app := v.app(); app != nil {
+		app.invalidations++
+	}
+}
+
+// invalidatePath invalidates v and each of its ancestors.
+func invalidatePath(v *View) {
+	for ; v != nil; v = v.Parent {
+		v.Invalidate()
+	}
+}
+
+// Invalidate marks every view, including children and modals, to be rendered
+// again on the next frame.
+func (app *App) Invalidate() {
+	app.invalidations++
+	for _, v := range app.views {
+		v.invalidateTree()
+	}
+	for _, modal := range app.modals {
+		modal.invalidateTree()
+	}
+}
+
+// invalidateLayout invalidates every view and clears the screen before the next
+// frame, for changes which may uncover parts of the screen no view draws over.
+func (app *App) invalidateLayout() {
+	app.clear = true
+	app.Invalidate()
+}
+
+func (v *View) invalidateTree() {
+	v.dirty = true
+	for _, child := range v.Children {
+		child.invalidateTree()
+	}
+}
+
+// needsDraw reports whether any visible view has been invalidated since the last
+// frame.
+func (app *App) needsDraw() bool {
+	if app.clear {
+		return true
+	}
+	for _, v := range app.views {
+		if v.visible && v.treeDirty() {
+			return true
+		}
+	}
+	for _, modal := range app.modals {
+		if modal.treeDirty() {
+			return true
+		}
+	}
+	return false
+}
+
+func (v *View) treeDirty() bool {
+	if v.dirty {
+		return true
+	}
+	for _, child := range v.Children {
+		if child.visible && child.treeDirty() {
+			return true
+		}
+	}
+	return false
+}
+
+// render runs the view's render function if it has been invalidated. Otherwise
+// the cells from the last render are kept.
+func (v *View) render() {
+	if !v.dirty {
+		return
+	}
+	v.Clear()
+	v.layout()
+	v.renderFunc(v)
+	v.dirty = false
+}
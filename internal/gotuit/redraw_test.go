@@ -0,0 +1,78 @@
+package gotuit
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// newListApp returns an App with a single full screen view rendering rows rows of
+// text, most of which fall outside the view.
+func newListApp(b *testing.B, rows int) (*App, *View) {
+	app, _ := newTestApp(b, 300, 100)
+	list := NewView("List", 0, 0, 300, 100, func(v *View) {
+		for i := range rows {
+			v.SetTextContent(0, i, fmt.Sprintf("%05d Lorem ipsum dolor sit amet", i), tcell.StyleDefault)
+		}
+	})
+	app.AddView(list)
+	app.Draw()
+	return app, list
+}
+
+func TestStaleSequenceTimeoutRedrawsNothing(t *testing.T) {
+	app, clock := newFakeClockApp(t)
+	v := newView("View")
+	v.Bind(NormalMode, "g g", "Top", "", func(*View) error { return nil })
+	app.AddView(v)
+	if err := app.Focus("View"); err != nil {
+		t.Fatal(err)
+	}
+
+	press(app, "g g")
+	app.Draw()
+	clock.Advance(SequenceTimeout)
+	app.runQueued()
+	if app.needsDraw() {
+		t.Fatal("The timeout of a finished sequence invalidated views")
+	}
+}
+
+func TestPostRedraws(t *testing.T) {
+	app, _ := newTestApp(t, 80, 24)
+	app.AddView(newView("A"))
+	b := newView("B")
+	app.AddView(b)
+	app.Draw()
+
+	mustPost(app, func(*App) {})
+	drain(t, app)
+	if !app.needsDraw() {
+		t.Fatal("A posted function invalidating nothing didn't redraw")
+	}
+
+	app.Draw()
+	app.handleEvent(&funcEvent{fn: func(*App) { b.Invalidate() }, redraw: true})
+	if app.views[0].dirty || !b.dirty {
+		t.Fatal("A posted function invalidating a view redrew other views")
+	}
+}
+
+func BenchmarkDraw(b *testing.B) {
+	b.Run("clean", func(b *testing.B) {
+		app, _ := newListApp(b, 10000)
+		b.ResetTimer()
+		for range b.N {
+			app.Draw()
+		}
+	})
+	b.Run("dirty", func(b *testing.B) {
+		app, list := newListApp(b, 10000)
+		b.ResetTimer()
+		for range b.N {
+			list.Invalidate()
+			app.Draw()
+		}
+	})
+}
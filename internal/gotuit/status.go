@@ -0,0 +1,214 @@
+package gotuit
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Severity is how important a status message is.
+type Severity int
+
+const (
+	SeverityInfo Severity = iota
+	SeverityWarn
+	SeverityError
+)
+
+var severityNames = map[Severity]string{
+	SeverityInfo:  "info",
+	SeverityWarn:  "warn",
+	SeverityError: "error",
+}
+
+func (s Severity) String() string {
+	return severityNames[s]
+}
+
+// Role returns the theme role status messages of severity s are drawn with.
+func (s Severity) Role() Role {
+	switch s {
+	case SeverityWarn:
+		return RoleStatusWarn
+	case SeverityError:
+		return RoleStatusError
+	}
+	return RoleStatusInfo
+}
+
+// StatusTimeout is how long a status message is shown for.
+var StatusTimeout = 5 * time.Second
+
+// statusHistorySize is the number of status messages kept by App.
+const statusHistorySize = 50
+
+// StatusMessage is a message shown to the user in the status line.
+type StatusMessage struct {
+	Text     string
+	Severity Severity
+	// Time is when the message was last posted.
+	Time time.Time
+	// Count is the number of times the message was posted in a row.
+	Count int
+}
+
+func (msg StatusMessage) String() string {
+	if msg.Count > 1 {
+		return fmt.Sprintf("%s (x%d)", msg.Text, msg.Count)
+	}
+	return msg.Text
+}
+
+// statusQueue is a ring buffer of the most recent status messages. It is safe to
+// use from any goroutine, since messages often come from log output.
+type statusQueue struct {
+	mu       sync.Mutex
+	messages [statusHistorySize]StatusMessage
+	// next is where the next message is stored, len the number stored.
+	next, len int
+}
+
+// push adds msg, or bumps the count of the latest message if msg repeats it.
+func (q *statusQueue) push(msg StatusMessage) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.len > 0 {
+		last := &q.messages[(q.next+statusHistorySize-1)%statusHistorySize]
+		if last.Text == msg.Text && last.Severity == msg.Severity {
+			last.Count++
+			last.Time = msg.Time
+			return
+		}
+	}
+
+	msg.Count = 1
+	q.messages[q.next] = msg
+	q.next = (q.next + 1) % statusHistorySize
+	q.len = min(q.len+1, statusHistorySize)
+}
+
+// history returns the stored messages, oldest first.
+func (q *statusQueue) history() []StatusMessage {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	messages := make([]StatusMessage, 0, q.len)
+	for i := range q.len {
+		messages = append(messages, q.messages[(q.next-q.len+i+statusHistorySize)%statusHistorySize])
+	}
+	return messages
+}
+
+// Notify shows text in the status line for StatusTimeout. Like App.Post, it is
+// safe to call from any goroutine.
+func (app *App) Notify(severity Severity, text string) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return
+	}
+	app.status.push(StatusMessage{Text: text, Severity: severity, Time: app.Now()})
+	// Redraw now to show the message, and once it has expired so it disappears
+	// on time.
+	app.enqueue(func(app *App) {
+		app.statusState.Changed()
+	})
+	app.After(StatusTimeout, func(app *App) {
+		app.statusState.Changed()
+	})
+}
+
+// Status returns the latest status message, unless it has expired.
+func (app *App) Status() (StatusMessage, bool) {
+	history := app.status.history()
+	if len(history) == 0 {
+		return StatusMessage{}, false
+	}
+	msg := history[len(history)-1]
+	if app.Now().Sub(msg.Time) >= StatusTimeout {
+		return StatusMessage{}, false
+	}
+	return msg, true
+}
+
+// StatusHistory returns the most recent status messages, oldest first, whether
+// they have expired or not.
+func (app *App) StatusHistory() []StatusMessage {
+	return app.status.history()
+}
+
+// Write shows p as an info status message, so App can be used as the output of
+// the log package.
+func (app *App) Write(p []byte) (n int, err error) {
+	app.Notify(SeverityInfo, string(p))
+	return len(p), nil
+}
+
+// StatusHandler is a slog.Handler showing log records as status messages, with a
+// severity matching the record's level.
+type StatusHandler struct {
+	app   *App
+	level slog.Leveler
+	attrs []slog.Attr
+	group string
+}
+
+// NewStatusHandler returns a handler showing records of level or above in the
+// status line of app.
+func NewStatusHandler(app *App, level slog.Leveler) *StatusHandler {
+	return &StatusHandler{app: app, level: level}
+}
+
+func (h *StatusHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level.Level()
+}
+
+func (h *StatusHandler) Handle(_ context.Context, r slog.Record) error {
+	severity := SeverityInfo
+	switch {
+	case r.Level >= slog.LevelError:
+		severity = SeverityError
+	case r.Level >= slog.LevelWarn:
+		severity = SeverityWarn
+	}
+
+	var b strings.Builder
+	b.WriteString(r.Message)
+	for _, a := range h.attrs {
+		fmt.Fprintf(&b, " %s=%v", a.Key, a.Value)
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		fmt.Fprintf(&b, " %s=%v", h.qualify(a.Key), a.Value)
+		return true
+	})
+
+	h.app.Notify(severity, b.String())
+	return nil
+}
+
+// qualify prefixes key with the handler's group.
+func (h *StatusHandler) qualify(key string) string {
+	if h.group == "" {
+		return key
+	}
+	return h.group + "." + key
+}
+
+func (h *StatusHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := *h
+	clone.attrs = slices.Clone(h.attrs)
+	for _, a := range attrs {
+		clone.attrs = append(clone.attrs, slog.Attr{Key: h.qualify(a.Key), Value: a.Value})
+	}
+	return &clone
+}
+
+func (h *StatusHandler) WithGroup(name string) slog.Handler {
+	clone := *h
+	clone.group = h.qualify(name)
+	return &clone
+}
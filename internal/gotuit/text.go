@@ -0,0 +1,166 @@
+package gotuit
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/mattn/go-runewidth"
+	"github.com/rivo/uniseg"
+)
+
+// Graphemes splits text into grapheme clusters, the characters a user sees. A
+// cluster may be made of several runes, like a letter followed by a combining
+// accent or an emoji with a skin tone modifier.
+func Graphemes(text string) []string {
+	clusters := []string{}
+	state := -1
+	for text != "" {
+		var cluster string
+		cluster, text, _, state = uniseg.FirstGraphemeClusterInString(text, state)
+		clusters = append(clusters, cluster)
+	}
+	return clusters
+}
+
+// ClusterWidth returns the number of columns a grapheme cluster takes up on the
+// screen, 2 for wide characters like CJK and most emoji. It agrees with tcell
+// about how wide the cluster is drawn.
+func ClusterWidth(cluster string) int {
+	return runewidth.StringWidth(cluster)
+}
+
+// StringWidth returns the number of columns text takes up on the screen.
+func StringWidth(text string) int {
+	if isPrintableASCII(text) {
+		return len(text)
+	}
+	width := 0
+	for _, cluster := range Graphemes(text) {
+		width += ClusterWidth(cluster)
+	}
+	return width
+}
+
+// isPrintableASCII reports whether text is made of printable ASCII characters
+// only, each of which is a cluster one column wide.
+func isPrintableASCII(text string) bool {
+	for i := 0; i < len(text); i++ {
+		if text[i] < ' ' || text[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// Ellipsis marks text cut short by Truncate.
+const Ellipsis = "…"
+
+type Alignment int
+
+const (
+	AlignLeft Alignment = iota
+	AlignCenter
+	AlignRight
+)
+
+// Wrap breaks text into lines at most width columns wide, breaking between words
+// where possible. Words too long for a line of their own are broken wherever
+// they reach width. The spaces a line is broken at are dropped, so every line is
+// a substring of text.
+func Wrap(text string, width int) []string {
+	return wrap(text, width, true)
+}
+
+// HardWrap breaks text into lines exactly width columns wide, ignoring word
+// boundaries. Only the last line may be shorter.
+func HardWrap(text string, width int) []string {
+	return wrap(text, width, false)
+}
+
+func wrap(text string, width int, words bool) []string {
+	if width < 1 {
+		return nil
+	}
+	lines := []string{}
+	for text != "" {
+		var line string
+		line, text = wrapLine(text, width, words)
+		lines = append(lines, line)
+	}
+	if len(lines) == 0 {
+		lines = append(lines, "")
+	}
+	return lines
+}
+
+// wrapLine splits the first line off text. A line always holds at least one
+// cluster, even if it's wider than width.
+func wrapLine(text string, width int, words bool) (line, rest string) {
+	col := 0
+	pos := 0
+	// breakAt is where the last run of spaces started, -1 if there was none.
+	breakAt := -1
+	prevSpace := false
+	state := -1
+	for pos < len(text) {
+		var cluster string
+		cluster, _, _, state = uniseg.FirstGraphemeClusterInString(text[pos:], state)
+		space := isSpace(cluster)
+		if words && space && !prevSpace {
+			breakAt = pos
+		}
+		prevSpace = space
+
+		w := ClusterWidth(cluster)
+		if col+w > width && !(words && space) {
+			if words && breakAt > 0 {
+				return text[:breakAt], strings.TrimLeftFunc(text[breakAt:], unicode.IsSpace)
+			}
+			if pos == 0 {
+				pos = len(cluster)
+			}
+			return text[:pos], text[pos:]
+		}
+		col += w
+		pos += len(cluster)
+	}
+	return text, ""
+}
+
+// Truncate cuts text down to at most width columns, ending it with Ellipsis if
+// anything was cut off.
+func Truncate(text string, width int) string {
+	if StringWidth(text) <= width {
+		return text
+	}
+	if width < 1 {
+		return ""
+	}
+
+	var b strings.Builder
+	col := 0
+	for _, cluster := range Graphemes(text) {
+		w := ClusterWidth(cluster)
+		if col+w > width-ClusterWidth(Ellipsis) {
+			break
+		}
+		b.WriteString(cluster)
+		col += w
+	}
+	b.WriteString(Ellipsis)
+	return b.String()
+}
+
+// Align pads text with spaces to fill width columns, placing it according to
+// align. Text wider than width is truncated.
+func Align(text string, width int, align Alignment) string {
+	text = Truncate(text, width)
+	gap := max(width-StringWidth(text), 0)
+	switch align {
+	case AlignCenter:
+		return strings.Repeat(" ", gap/2) + text + strings.Repeat(" ", gap-gap/2)
+	case AlignRight:
+		return strings.Repeat(" ", gap) + text
+	}
+	return text + strings.Repeat(" ", gap)
+}
@@ -0,0 +1,124 @@
+package gotuit
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestStringWidth(t *testing.T) {
+	for _, tc := range []struct {
+		text  string
+		width int
+	}{
+		{"", 0},
+		{"hello", 5},
+		{"日本語", 6},
+		{"a日b", 4},
+		{"e\u0301", 1},
+		{"👍", 2},
+	} {
+		if got := StringWidth(tc.text); got != tc.width {
+			t.Errorf("Got width %d for %q, want %d", got, tc.text, tc.width)
+		}
+	}
+}
+
+func TestGraphemes(t *testing.T) {
+	got := Graphemes("ae\u0301日")
+	want := []string{"a", "e\u0301", "日"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("Got clusters %q, want %q", got, want)
+	}
+}
+
+func TestWrap(t *testing.T) {
+	for _, tc := range []struct {
+		text  string
+		width int
+		want  []string
+	}{
+		{"", 5, []string{""}},
+		{"hello", 0, nil},
+		{"hello", 5, []string{"hello"}},
+		{"hello world", 5, []string{"hello", "world"}},
+		{"hello   world", 7, []string{"hello", "world"}},
+		{"a bc def", 4, []string{"a bc", "def"}},
+		{"abcdefgh", 3, []string{"abc", "def", "gh"}},
+		{"ab cdefgh", 4, []string{"ab", "cdef", "gh"}},
+		{"日本語 テキスト", 6, []string{"日本語", "テキス", "ト"}},
+		{"a 日本", 3, []string{"a", "日", "本"}},
+		{"日本", 1, []string{"日", "本"}},
+		{"e\u0301e\u0301e\u0301", 2, []string{"e\u0301e\u0301", "e\u0301"}},
+	} {
+		if got := Wrap(tc.text, tc.width); !slices.Equal(got, tc.want) {
+			t.Errorf("Got %q wrapping %q to %d, want %q", got, tc.text, tc.width, tc.want)
+		}
+	}
+}
+
+func TestHardWrap(t *testing.T) {
+	for _, tc := range []struct {
+		text  string
+		width int
+		want  []string
+	}{
+		{"abcdef", 4, []string{"abcd", "ef"}},
+		{"ab cd", 3, []string{"ab ", "cd"}},
+		{"日本語", 4, []string{"日本", "語"}},
+		{"a日本", 2, []string{"a", "日", "本"}},
+	} {
+		if got := HardWrap(tc.text, tc.width); !slices.Equal(got, tc.want) {
+			t.Errorf("Got %q hard wrapping %q to %d, want %q", got, tc.text, tc.width, tc.want)
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	for _, tc := range []struct {
+		text  string
+		width int
+		want  string
+	}{
+		{"hello", 5, "hello"},
+		{"hello", 10, "hello"},
+		{"hello world", 5, "hell…"},
+		{"hello", 0, ""},
+		{"hello", 1, "…"},
+		{"日本語", 6, "日本語"},
+		{"日本語", 5, "日本…"},
+		{"日本語", 4, "日…"},
+		{"日本", 1, "…"},
+		{"e\u0301e\u0301e\u0301", 2, "e\u0301…"},
+	} {
+		got := Truncate(tc.text, tc.width)
+		if got != tc.want {
+			t.Errorf("Got %q truncating %q to %d, want %q", got, tc.text, tc.width, tc.want)
+		}
+		if StringWidth(got) > tc.width {
+			t.Errorf("Truncating %q to %d is %d columns wide", tc.text, tc.width, StringWidth(got))
+		}
+	}
+}
+
+func TestAlign(t *testing.T) {
+	for _, tc := range []struct {
+		text  string
+		width int
+		align Alignment
+		want  string
+	}{
+		{"ab", 5, AlignLeft, "ab   "},
+		{"ab", 5, AlignCenter, " ab  "},
+		{"ab", 5, AlignRight, "   ab"},
+		{"日本", 5, AlignLeft, "日本 "},
+		{"日本", 5, AlignCenter, "日本 "},
+		{"日本", 6, AlignCenter, " 日本 "},
+		{"日本", 6, AlignRight, "  日本"},
+		{"日本語x", 5, AlignRight, "日本…"},
+		{"abc", 3, AlignCenter, "abc"},
+	} {
+		if got := Align(tc.text, tc.width, tc.align); got != tc.want {
+			t.Errorf("Got %q aligning %q to %d, want %q", got, tc.text, tc.width, tc.want)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package gotuit
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// TextInput is a single line text editor with readline style keys. Every view
+// has one, which receives the keys the view doesn't bind while in InputMode.
+//
+//	Left, C-b    move left           Right, C-f   move right
+//	Home, C-a    move to start       End, C-e     move to end
+//	M-b          move a word left    M-f          move a word right
+//	Backspace    delete left         Delete, C-d  delete right
+//	C-w          delete word left    C-u, C-k     delete to start, to end
+//
+// The text is edited a grapheme cluster at a time, so the cursor never ends up
+// between a character and its combining marks.
+type TextInput struct {
+	// Placeholder is shown, dimmed, while the input is empty.
+	Placeholder string
+
+	text   []string
+	cursor int
+	// scroll is the index of the first cluster drawn, so the cursor stays visible
+	// when the text is wider than the input.
+	scroll int
+}
+
+func NewTextInput() *TextInput {
+	return &TextInput{text: []string{}}
+}
+
+var textInputKeys = map[KeyChord]func(*TextInput){}
+
+func init() {
+	bind := func(keys string, action func(*TextInput)) {
+		for _, kc := range mustParseKeys(keys) {
+			textInputKeys[kc] = action
+		}
+	}
+	bind("Left C-b", func(t *TextInput) { t.SetCursor(t.cursor - 1) })
+	bind("Right C-f", func(t *TextInput) { t.SetCursor(t.cursor + 1) })
+	bind("Home C-a", func(t *TextInput) { t.SetCursor(0) })
+	bind("End C-e", func(t *TextInput) { t.SetCursor(len(t.text)) })
+	bind("M-b", func(t *TextInput) { t.SetCursor(t.wordStart()) })
+	bind("M-f", func(t *TextInput) { t.SetCursor(t.wordEnd()) })
+	bind("Backspace", func(t *TextInput) { t.delete(t.cursor-1, t.cursor) })
+	bind("Delete C-d", func(t *TextInput) { t.delete(t.cursor, t.cursor+1) })
+	bind("C-w", func(t *TextInput) { t.delete(t.wordStart(), t.cursor) })
+	bind("C-u", func(t *TextInput) { t.delete(0, t.cursor) })
+	bind("C-k", func(t *TextInput) { t.delete(t.cursor, len(t.text)) })
+}
+
+func (t *TextInput) Text() string {
+	return strings.Join(t.text, "")
+}
+
+// SetText replaces the text and moves the cursor to its end.
+func (t *TextInput) SetText(text string) {
+	t.text = Graphemes(text)
+	t.cursor = len(t.text)
+	t.scroll = 0
+}
+
+func (t *TextInput) Clear() {
+	t.SetText("")
+}
+
+// Cursor returns the position of the cursor, in grapheme clusters from the start
+// of the text.
+func (t *TextInput) Cursor() int {
+	return t.cursor
+}
+
+// SetCursor moves the cursor to pos, clamped to the text.
+func (t *TextInput) SetCursor(pos int) {
+	t.cursor = max(min(pos, len(t.text)), 0)
+}
+
+// HandleKey edits the text according to key and reports whether key did
+// anything.
+func (t *TextInput) HandleKey(key KeyChord) bool {
+	if key.isPlainRune() {
+		t.Insert(string(key.Rune))
+		return true
+	}
+	action, ok := textInputKeys[key]
+	if !ok {
+		return false
+	}
+	action(t)
+	return true
+}
+
+// Insert inserts text at the cursor and moves the cursor past it. Text is joined
+// with the clusters around it, so typing a combining mark after a letter adds the
+// mark to the letter.
+func (t *TextInput) Insert(text string) {
+	head := strings.Join(t.text[:t.cursor], "") + text
+	tail := strings.Join(t.text[t.cursor:], "")
+	t.text = Graphemes(head + tail)
+	t.cursor = min(len(Graphemes(head)), len(t.text))
+}
+
+// delete removes the clusters from start up to end and leaves the cursor at start.
+func (t *TextInput) delete(start, end int) {
+	start = max(start, 0)
+	end = min(end, len(t.text))
+	if start >= end {
+		return
+	}
+	t.text = append(t.text[:start], t.text[end:]...)
+	t.cursor = start
+}
+
+// wordStart returns the start of the word before the cursor, skipping spaces
+// between it and the cursor.
+func (t *TextInput) wordStart() int {
+	pos := t.cursor
+	for pos > 0 && isSpace(t.text[pos-1]) {
+		pos--
+	}
+	for pos > 0 && !isSpace(t.text[pos-1]) {
+		pos--
+	}
+	return pos
+}
+
+// wordEnd returns the end of the word after the cursor, skipping spaces between
+// it and the cursor.
+func (t *TextInput) wordEnd() int {
+	pos := t.cursor
+	for pos < len(t.text) && isSpace(t.text[pos]) {
+		pos++
+	}
+	for pos < len(t.text) && !isSpace(t.text[pos]) {
+		pos++
+	}
+	return pos
+}
+
+func isSpace(cluster string) bool {
+	r, _ := utf8.DecodeRuneInString(cluster)
+	return unicode.IsSpace(r)
+}
+
+// Render draws the input on v at x, y, using at most width columns, and puts the
+// terminal cursor on the input's cursor. The text scrolls horizontally to keep
+// the cursor in view.
+func (t *TextInput) Render(v *View, x, y, width int, style tcell.Style) {
+	if width < 1 {
+		return
+	}
+
+	if len(t.text) == 0 && t.Placeholder != "" {
+		t.scroll = 0
+		v.drawText(x, y, t.Placeholder, width, style.Dim(true))
+		v.ShowCursorAt(x, y)
+		return
+	}
+
+	// Scroll until the text before the cursor, and the cursor itself, fit.
+	t.scroll = min(t.scroll, t.cursor)
+	for t.scroll < t.cursor && t.width(t.scroll, t.cursor)+1 > width {
+		t.scroll++
+	}
+
+	v.drawText(x, y, strings.Join(t.text[t.scroll:], ""), width, style)
+	v.ShowCursorAt(x+t.width(t.scroll, t.cursor), y)
+}
+
+// width returns the number of columns taken by the clusters from start up to end.
+func (t *TextInput) width(start, end int) int {
+	width := 0
+	for _, cluster := range t.text[start:end] {
+		width += ClusterWidth(cluster)
+	}
+	return width
+}
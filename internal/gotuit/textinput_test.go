@@ -0,0 +1,129 @@
+package gotuit
+
+import (
+	"testing"
+)
+
+func TestTextInputEdits(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		text   string
+		cursor int
+		keys   string
+		want   string
+		// wantCursor is the cursor after keys, in clusters.
+		wantCursor int
+	}{
+		{"type", "", 0, "a b", "ab", 2},
+		{"type in the middle", "ac", 1, "b", "abc", 2},
+		{"left and right", "abc", 3, "Left Left C-b Right C-f", "abc", 2},
+		{"left past the start", "ab", 0, "Left", "ab", 0},
+		{"right past the end", "ab", 2, "Right", "ab", 2},
+		{"home and end", "abc", 1, "Home", "abc", 0},
+		{"C-a and C-e", "abc", 1, "C-a x C-e y", "xabcy", 5},
+		{"word left", "foo bar  baz", 12, "M-b", "foo bar  baz", 9},
+		{"word left over spaces", "foo bar  baz", 9, "M-b", "foo bar  baz", 4},
+		{"word right", "foo bar  baz", 0, "M-f", "foo bar  baz", 3},
+		{"word right over spaces", "foo bar  baz", 7, "M-f", "foo bar  baz", 12},
+		{"backspace", "abc", 2, "Backspace", "ac", 1},
+		{"C-h", "abc", 2, "C-h", "ac", 1},
+		{"backspace at the start", "abc", 0, "Backspace", "abc", 0},
+		{"delete", "abc", 1, "Delete", "ac", 1},
+		{"C-d at the end", "abc", 3, "C-d", "abc", 3},
+		{"delete word", "foo bar  ", 9, "C-w", "foo ", 4},
+		{"delete word at the start", "foo", 0, "C-w", "foo", 0},
+		{"delete to start", "foo bar", 4, "C-u", "bar", 0},
+		{"delete to end", "foo bar", 3, "C-k", "foo", 3},
+		{"wide runes", "日本語", 3, "Left Backspace", "日語", 1},
+		{"combining marks", "e\u0301x", 2, "Left Backspace", "x", 0},
+		{"space", "ab", 1, "Space", "a b", 2},
+	} {
+		input := NewTextInput()
+		input.SetText(tc.text)
+		input.SetCursor(tc.cursor)
+		chords, err := ParseKeys(tc.keys)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, kc := range chords {
+			if !input.HandleKey(kc) {
+				t.Fatalf("%s: '%s' wasn't handled", tc.name, kc)
+			}
+		}
+		if input.Text() != tc.want || input.Cursor() != tc.wantCursor {
+			t.Errorf("%s: Got '%s' with the cursor at %d, want '%s' with the cursor at %d",
+				tc.name, input.Text(), input.Cursor(), tc.want, tc.wantCursor)
+		}
+	}
+}
+
+func TestTextInputIgnoresOtherKeys(t *testing.T) {
+	input := NewTextInput()
+	input.SetText("abc")
+	for _, keys := range []string{"Enter", "Esc", "Up", "C-x", "M-Enter"} {
+		chords, err := ParseKeys(keys)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if input.HandleKey(chords[0]) {
+			t.Errorf("'%s' was handled", keys)
+		}
+	}
+	if input.Text() != "abc" || input.Cursor() != 3 {
+		t.Fatalf("Got '%s' with the cursor at %d", input.Text(), input.Cursor())
+	}
+}
+
+func TestTextInputInsert(t *testing.T) {
+	for _, tc := range []struct {
+		text   string
+		cursor int
+		insert string
+		want   string
+		// wantCursor is the cursor after inserting, in clusters.
+		wantCursor int
+	}{
+		{"", 0, "hello", "hello", 5},
+		{"ad", 1, "bc", "abcd", 3},
+		{"e", 1, "\u0301", "e\u0301", 1},
+		{"ex", 1, "\u0301", "e\u0301x", 1},
+		{"日語", 1, "本", "日本語", 2},
+	} {
+		input := NewTextInput()
+		input.SetText(tc.text)
+		input.SetCursor(tc.cursor)
+		input.Insert(tc.insert)
+		if input.Text() != tc.want || input.Cursor() != tc.wantCursor {
+			t.Errorf("Inserting %q into %q at %d: Got %q with the cursor at %d, want %q with the cursor at %d",
+				tc.insert, tc.text, tc.cursor, input.Text(), input.Cursor(), tc.want, tc.wantCursor)
+		}
+	}
+}
+
+func TestTextInputWordBounds(t *testing.T) {
+	for _, tc := range []struct {
+		text   string
+		cursor int
+		start  int
+		end    int
+	}{
+		{"", 0, 0, 0},
+		{"foo", 0, 0, 3},
+		{"foo", 1, 0, 3},
+		{"foo", 3, 0, 3},
+		{"foo bar", 3, 0, 7},
+		{"foo bar", 4, 0, 7},
+		{"foo bar", 5, 4, 7},
+		{"  foo  ", 7, 2, 7},
+		{"日本 語", 3, 0, 4},
+		{"a\tb", 2, 0, 3},
+	} {
+		input := NewTextInput()
+		input.SetText(tc.text)
+		input.SetCursor(tc.cursor)
+		if start, end := input.wordStart(), input.wordEnd(); start != tc.start || end != tc.end {
+			t.Errorf("Got word bounds %d, %d for %q at %d, want %d, %d",
+				start, end, tc.text, tc.cursor, tc.start, tc.end)
+		}
+	}
+}
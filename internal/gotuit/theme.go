@@ -0,0 +1,278 @@
+package gotuit
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// Role names what a style is used for, so views can ask the theme for a style
+// instead of hard coding colors.
+type Role string
+
+const (
+	RoleNormal        Role = "normal"
+	RoleTitle         Role = "title"
+	RoleBorder        Role = "border"
+	RoleFocusedBorder Role = "focused_border"
+	RolePanel         Role = "panel"
+	RoleStatusBar     Role = "status_bar"
+	RoleSelection     Role = "selection"
+	RoleMarked        Role = "marked"
+	RoleSearchMatch   Role = "search_match"
+	RoleCompleted     Role = "completed"
+	RoleOverdue       Role = "overdue"
+	RoleStatusInfo    Role = "status_info"
+	RoleStatusWarn    Role = "status_warn"
+	RoleStatusError   Role = "status_error"
+)
+
+// Roles lists every role a theme may style.
+var Roles = []Role{
+	RoleNormal,
+	RoleTitle,
+	RoleBorder,
+	RoleFocusedBorder,
+	RolePanel,
+	RoleStatusBar,
+	RoleSelection,
+	RoleMarked,
+	RoleSearchMatch,
+	RoleCompleted,
+	RoleOverdue,
+	RoleStatusInfo,
+	RoleStatusWarn,
+	RoleStatusError,
+}
+
+// Theme maps roles to styles. Roles missing from a theme use RoleNormal.
+type Theme struct {
+	Name   string
+	Styles map[Role]tcell.Style
+}
+
+func (t *Theme) Style(role Role) tcell.Style {
+	if style, ok := t.Styles[role]; ok {
+		return style
+	}
+	return t.Styles[RoleNormal]
+}
+
+// Clone returns a copy of t which can be modified without changing t.
+func (t *Theme) Clone(name string) *Theme {
+	return &Theme{Name: name, Styles: maps.Clone(t.Styles)}
+}
+
+// MergeStyles returns base with every color and attribute set in over applied on
+// top of it.
+func MergeStyles(base, over tcell.Style) tcell.Style {
+	fg, bg, attrs := over.Decompose()
+	_, _, baseAttrs := base.Decompose()
+	if fg != tcell.ColorDefault {
+		base = base.Foreground(fg)
+	}
+	if bg != tcell.ColorDefault {
+		base = base.Background(bg)
+	}
+	return base.Attributes(baseAttrs | attrs)
+}
+
+func DefaultTheme() *Theme {
+	panel := tcell.StyleDefault.
+		Background(tcell.NewHexColor(0x284B63)).
+		Foreground(tcell.NewHexColor(0xF4F9E9))
+
+	return &Theme{
+		Name: "default",
+		Styles: map[Role]tcell.Style{
+			RoleNormal:        tcell.StyleDefault,
+			RoleTitle:         tcell.StyleDefault,
+			RoleBorder:        tcell.StyleDefault,
+			RoleFocusedBorder: tcell.StyleDefault.Foreground(tcell.NewHexColor(0x5BC0BE)),
+			RolePanel:         panel,
+			RoleStatusBar:     panel,
+			RoleSelection:     tcell.StyleDefault.Background(tcell.ColorGray).Foreground(tcell.NewHexColor(0xF4F9E9)),
+			RoleMarked:        tcell.StyleDefault.Background(tcell.ColorNavy),
+			RoleSearchMatch:   tcell.StyleDefault.Background(tcell.ColorDarkGreen),
+			RoleCompleted:     tcell.StyleDefault.Foreground(tcell.ColorDarkGray),
+			RoleOverdue:       tcell.StyleDefault.Foreground(tcell.NewHexColor(0xE07A5F)),
+			RoleStatusInfo:    panel,
+			RoleStatusWarn:    panel.Foreground(tcell.NewHexColor(0xF2CC8F)).Bold(true),
+			RoleStatusError:   panel.Foreground(tcell.NewHexColor(0xE07A5F)).Bold(true),
+		},
+	}
+}
+
+func LightTheme() *Theme {
+	normal := tcell.StyleDefault.
+		Background(tcell.NewHexColor(0xFAFAFA)).
+		Foreground(tcell.NewHexColor(0x1B263B))
+	panel := normal.Background(tcell.NewHexColor(0xD8E2EA))
+
+	return &Theme{
+		Name: "light",
+		Styles: map[Role]tcell.Style{
+			RoleNormal:        normal,
+			RoleTitle:         normal.Bold(true),
+			RoleBorder:        normal.Foreground(tcell.NewHexColor(0x9AA5B1)),
+			RoleFocusedBorder: normal.Foreground(tcell.NewHexColor(0x1D6FA3)),
+			RolePanel:         panel,
+			RoleStatusBar:     panel,
+			RoleSelection:     normal.Background(tcell.NewHexColor(0xB3D4FC)),
+			RoleMarked:        normal.Background(tcell.NewHexColor(0xE0E7FF)),
+			RoleSearchMatch:   normal.Background(tcell.NewHexColor(0xFFE066)),
+			RoleCompleted:     normal.Foreground(tcell.NewHexColor(0x9E9E9E)),
+			RoleOverdue:       normal.Foreground(tcell.NewHexColor(0xC0392B)).Bold(true),
+			RoleStatusInfo:    panel,
+			RoleStatusWarn:    panel.Foreground(tcell.NewHexColor(0x9A6700)).Bold(true),
+			RoleStatusError:   panel.Foreground(tcell.NewHexColor(0xC0392B)).Bold(true),
+		},
+	}
+}
+
+func HighContrastTheme() *Theme {
+	normal := tcell.StyleDefault.Background(tcell.ColorBlack).Foreground(tcell.ColorWhite)
+
+	return &Theme{
+		Name: "high-contrast",
+		Styles: map[Role]tcell.Style{
+			RoleNormal:        normal,
+			RoleTitle:         normal.Bold(true),
+			RoleBorder:        normal,
+			RoleFocusedBorder: normal.Foreground(tcell.ColorYellow).Bold(true),
+			RolePanel:         normal,
+			RoleStatusBar:     normal.Reverse(true),
+			RoleSelection:     normal.Reverse(true),
+			RoleMarked:        normal.Background(tcell.ColorBlue),
+			RoleSearchMatch:   normal.Background(tcell.ColorYellow).Foreground(tcell.ColorBlack),
+			RoleCompleted:     normal.Foreground(tcell.ColorSilver),
+			RoleOverdue:       normal.Foreground(tcell.ColorRed).Bold(true),
+			RoleStatusInfo:    normal.Reverse(true),
+			RoleStatusWarn:    normal.Background(tcell.ColorYellow).Foreground(tcell.ColorBlack),
+			RoleStatusError:   normal.Background(tcell.ColorRed).Foreground(tcell.ColorWhite).Bold(true),
+		},
+	}
+}
+
+// BuiltinThemes returns a fresh copy of every theme shipped with gotuit.
+func BuiltinThemes() []*Theme {
+	return []*Theme{DefaultTheme(), LightTheme(), HighContrastTheme()}
+}
+
+// StyleSpec describes a style in a config file. Colors are tcell color names or
+// hex values like "#284B63". Empty colors use the terminal default.
+type StyleSpec struct {
+	Fg            string `json:"fg"`
+	Bg            string `json:"bg"`
+	Bold          bool   `json:"bold"`
+	Dim           bool   `json:"dim"`
+	Italic        bool   `json:"italic"`
+	Underline     bool   `json:"underline"`
+	Reverse       bool   `json:"reverse"`
+	Strikethrough bool   `json:"strikethrough"`
+}
+
+func parseColor(name string) (tcell.Color, error) {
+	if name == "" || name == "default" {
+		return tcell.ColorDefault, nil
+	}
+	color := tcell.GetColor(name)
+	if color == tcell.ColorDefault {
+		return color, fmt.Errorf("Unknown color '%s'", name)
+	}
+	return color, nil
+}
+
+func (s StyleSpec) Style() (tcell.Style, error) {
+	fg, err := parseColor(s.Fg)
+	if err != nil {
+		return tcell.StyleDefault, err
+	}
+	bg, err := parseColor(s.Bg)
+	if err != nil {
+		return tcell.StyleDefault, err
+	}
+
+	return tcell.StyleDefault.
+		Foreground(fg).
+		Background(bg).
+		Bold(s.Bold).
+		Dim(s.Dim).
+		Italic(s.Italic).
+		Underline(s.Underline).
+		Reverse(s.Reverse).
+		StrikeThrough(s.Strikethrough), nil
+}
+
+// NewTheme builds a theme named name from base, replacing the style of every
+// role in specs.
+func NewTheme(name string, base *Theme, specs map[Role]StyleSpec) (*Theme, error) {
+	theme := base.Clone(name)
+	for role, spec := range specs {
+		if !slices.Contains(Roles, role) {
+			return nil, fmt.Errorf("Theme '%s': unknown role '%s'", name, role)
+		}
+		style, err := spec.Style()
+		if err != nil {
+			return nil, fmt.Errorf("Theme '%s', role '%s': %w", name, role, err)
+		}
+		theme.Styles[role] = style
+	}
+	return theme, nil
+}
+
+// monochromeAttrs keeps roles distinguishable when a theme is drawn without
+// color.
+var monochromeAttrs = map[Role]tcell.AttrMask{
+	RoleTitle:         tcell.AttrBold,
+	RoleFocusedBorder: tcell.AttrBold,
+	RoleStatusBar:     tcell.AttrReverse,
+	RoleSelection:     tcell.AttrReverse,
+	RoleMarked:        tcell.AttrBold,
+	RoleSearchMatch:   tcell.AttrUnderline,
+	RoleCompleted:     tcell.AttrDim,
+	RoleOverdue:       tcell.AttrBold | tcell.AttrItalic,
+	RoleStatusInfo:    tcell.AttrReverse,
+	RoleStatusWarn:    tcell.AttrReverse | tcell.AttrBold,
+	RoleStatusError:   tcell.AttrReverse | tcell.AttrBold | tcell.AttrUnderline,
+}
+
+// Degrade returns a copy of t suitable for a terminal supporting the given number
+// of colors, as reported by tcell.Screen.Colors. Colors are replaced with the
+// nearest color of the terminal's palette. With fewer than 8 colors, all colors
+// are dropped and roles are told apart with attributes like reverse and bold.
+func (t *Theme) Degrade(colors int) *Theme {
+	if colors >= 1<<24 {
+		return t
+	}
+
+	palette := []tcell.Color{}
+	for i := range min(colors, 256) {
+		palette = append(palette, tcell.PaletteColor(i))
+	}
+	nearest := map[tcell.Color]tcell.Color{}
+	fit := func(c tcell.Color) tcell.Color {
+		if c == tcell.ColorDefault || len(palette) < 8 {
+			return tcell.ColorDefault
+		}
+		if _, ok := nearest[c]; !ok {
+			nearest[c] = tcell.FindColor(c, palette)
+		}
+		return nearest[c]
+	}
+
+	degraded := t.Clone(t.Name)
+	for role, style := range t.Styles {
+		fg, bg, attrs := style.Decompose()
+		if len(palette) < 8 {
+			attrs |= monochromeAttrs[role]
+		}
+		degraded.Styles[role] = tcell.StyleDefault.
+			Foreground(fit(fg)).
+			Background(fit(bg)).
+			Attributes(attrs)
+	}
+	return degraded
+}
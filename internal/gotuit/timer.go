@@ -0,0 +1,113 @@
+package gotuit
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock tells the time and runs functions after a delay. App uses the system
+// clock unless another one is set with App.SetClock, like a fake clock which a
+// test advances by hand.
+type Clock interface {
+	Now() time.Time
+	// AfterFunc calls f in its own goroutine once d has passed.
+	AfterFunc(d time.Duration, f func()) Stopper
+}
+
+// Stopper stops a pending call scheduled by Clock.AfterFunc. *time.Timer is a
+// Stopper.
+type Stopper interface {
+	Stop() bool
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) AfterFunc(d time.Duration, f func()) Stopper {
+	return time.AfterFunc(d, f)
+}
+
+// Timer is a handle for a function scheduled with App.After or App.Every.
+type Timer struct {
+	mu       sync.Mutex
+	canceled bool
+	pending  Stopper
+}
+
+// Cancel stops the timer. The function won't be called again, even if it is
+// already waiting in the event queue.
+func (t *Timer) Cancel() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.canceled = true
+	if t.pending != nil {
+		t.pending.Stop()
+	}
+}
+
+func (t *Timer) isCanceled() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.canceled
+}
+
+// Now returns the current time according to the app's clock.
+func (app *App) Now() time.Time {
+	return app.clock.Now()
+}
+
+// SetClock replaces the clock used for App.Now, App.After and App.Every. Timers
+// already scheduled keep using the old clock.
+func (app *App) SetClock(clock Clock) {
+	app.clock = clock
+}
+
+// After calls fn on the event loop once d has passed. The call is never dropped,
+// even when the event queue is full. Unlike App.Post, only the views fn
+// invalidates are redrawn.
+func (app *App) After(d time.Duration, fn func(*App)) *Timer {
+	t := &Timer{}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.pending = app.clock.AfterFunc(d, func() {
+		app.enqueue(func(app *App) {
+			if !t.isCanceled() {
+				fn(app)
+			}
+		})
+	})
+	return t
+}
+
+// Every calls fn on the event loop every time d passes, until the timer is
+// canceled. Ticks are skipped rather than queued up if the event queue is full.
+// Like App.After, only the views fn invalidates are redrawn.
+func (app *App) Every(d time.Duration, fn func(*App)) *Timer {
+	t := &Timer{}
+	clock := app.clock
+
+	var tick func()
+	tick = func() {
+		t.mu.Lock()
+		if t.canceled {
+			t.mu.Unlock()
+			return
+		}
+		t.pending = clock.AfterFunc(d, tick)
+		t.mu.Unlock()
+
+		app.screen.PostEvent(&funcEvent{t: time.Now(), fn: func(app *App) {
+			if !t.isCanceled() {
+				fn(app)
+			}
+		}})
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.pending = clock.AfterFunc(d, tick)
+	return t
+}
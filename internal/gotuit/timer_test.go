@@ -0,0 +1,161 @@
+package gotuit
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a Clock which only moves when advanced.
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+}
+
+type fakeTimer struct {
+	clock *fakeClock
+	at    time.Time
+	f     func()
+	done  bool
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) AfterFunc(d time.Duration, f func()) Stopper {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := &fakeTimer{clock: c, at: c.now.Add(d), f: f}
+	c.timers = append(c.timers, t)
+	return t
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	stopped := !t.done
+	t.done = true
+	return stopped
+}
+
+// Advance moves the clock forward by d, calling the functions which became due.
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	due := []func(){}
+	for _, t := range c.timers {
+		if !t.done && !t.at.After(c.now) {
+			t.done = true
+			due = append(due, t.f)
+		}
+	}
+	c.mu.Unlock()
+
+	for _, f := range due {
+		f()
+	}
+}
+
+func newFakeClockApp(t *testing.T) (*App, *fakeClock) {
+	app, _ := newTestApp(t, 80, 24)
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)}
+	app.SetClock(clock)
+	return app, clock
+}
+
+func TestAfterFiresOnce(t *testing.T) {
+	app, clock := newFakeClockApp(t)
+	calls := 0
+	app.After(time.Second, func(*App) {
+		calls++
+	})
+
+	clock.Advance(time.Second - time.Millisecond)
+	drain(t, app)
+	if calls != 0 {
+		t.Fatalf("After ran %d times before it was due", calls)
+	}
+
+	clock.Advance(time.Millisecond)
+	drain(t, app)
+	clock.Advance(time.Minute)
+	drain(t, app)
+	if calls != 1 {
+		t.Fatalf("After ran %d times, want 1", calls)
+	}
+}
+
+func TestEveryRearms(t *testing.T) {
+	app, clock := newFakeClockApp(t)
+	calls := 0
+	timer := app.Every(time.Second, func(*App) {
+		calls++
+	})
+
+	for want := 1; want <= 3; want++ {
+		clock.Advance(time.Second)
+		drain(t, app)
+		if calls != want {
+			t.Fatalf("Every ran %d times after %d ticks", calls, want)
+		}
+	}
+
+	timer.Cancel()
+	clock.Advance(time.Second)
+	drain(t, app)
+	if calls != 3 {
+		t.Fatalf("Every ran %d times after being canceled, want 3", calls)
+	}
+}
+
+func TestCancelSuppressesQueuedCall(t *testing.T) {
+	app, clock := newFakeClockApp(t)
+	calls := 0
+	timer := app.After(time.Second, func(*App) {
+		calls++
+	})
+
+	// The call is now waiting in the event queue.
+	clock.Advance(time.Second)
+	timer.Cancel()
+	drain(t, app)
+	if calls != 0 {
+		t.Fatalf("Canceled timer ran %d times", calls)
+	}
+}
+
+// fillQueue posts functions until the event queue is full. The last one to fit
+// quits the main loop.
+func fillQueue(app *App) {
+	quit := func(app *App) {
+		app.Quit()
+	}
+	for app.Post(func(*App) {}) == nil {
+	}
+	// Make room for the quit, which goes last.
+	app.screen.PollEvent()
+	if err := app.Post(quit); err != nil {
+		panic(err)
+	}
+}
+
+func TestAfterFiresWithFullQueue(t *testing.T) {
+	app, clock := newFakeClockApp(t)
+	calls := 0
+	app.After(time.Second, func(*App) {
+		calls++
+	})
+
+	fillQueue(app)
+	clock.Advance(time.Second)
+	if err := app.MainLoop(); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 1 {
+		t.Fatalf("After ran %d times with a full event queue, want 1", calls)
+	}
+}
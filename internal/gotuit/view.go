@@ -3,11 +3,22 @@ package gotuit
 import (
 	"errors"
 	"fmt"
-	"log/slog"
+	"slices"
+	"strconv"
+	"time"
 
 	"github.com/gdamore/tcell/v2"
 )
 
+// SequenceTimeout is how long a view waits for the next key of a multi-key
+// sequence before giving up on it.
+var SequenceTimeout = time.Second
+
+// MaxCount is the largest count a numeric prefix gives. Longer runs of digits
+// are clamped to it, like in vim, so a count can't overflow or ask for millions
+// of repeats.
+const MaxCount = 9999
+
 type View struct {
 	Name             string
 	App              *App
@@ -23,89 +34,61 @@ type View struct {
 	paddingr         int
 	paddingb         int
 	paddingl         int
-	inputBuffer      []rune
-	InputCursor      int
-	fillColor        tcell.Color
-	borderColor      tcell.Color
+	Input            *TextInput
+	fillRole         Role
 	visible          bool
 	Parent           *View
 	Children         []*View
-	focusedview      string
+	pendingKeys      []KeyChord
+	pendingCount     int
+	pendingSeq       int
+	count            int
+	mouseHandler     func(*View, MouseEvent) error
+	focusable        bool
+	dirty            bool
+	component        Component
+	laidOut          bool
+	layoutw, layouth int
+	onFocus          func(*View)
+	onBlur           func(*View)
 }
 
 func NewView(name string, x, y, w, h int, renderFunc func(*View)) *View {
 	v := View{
-		Name:        name,
-		Mode:        NormalMode,
-		x:           x,
-		y:           y,
-		w:           w,
-		h:           h,
-		cells:       make([]cell, 0),
-		renderFunc:  renderFunc,
-		border:      true,
-		inputBuffer: []rune{},
-		fillColor:   tcell.ColorDefault,
-		borderColor: tcell.ColorDefault,
-		visible:     true,
-		focusedview: name,
+		Name:       name,
+		Mode:       NormalMode,
+		x:          x,
+		y:          y,
+		w:          w,
+		h:          h,
+		renderFunc: renderFunc,
+		border:     true,
+		Input:      NewTextInput(),
+		fillRole:   RoleNormal,
+		visible:    true,
+		focusable:  true,
+		dirty:      true,
 	}
 
 	return &v
 }
 
-func (v *View) GetFocusedView() (*View, error) {
-	if v.focusedview == v.Name {
-		return v, nil
-	}
-
-	for _, c := range v.Children {
-		if c.Name == v.focusedview {
-			return c, nil
-		}
-	}
-	return nil, errors.New("Child not found")
-}
-
-// Focus sets the View.focusedview attribute to viewName.
-//
-// viewName must be the name of the calling view or the name of a view contained in
-// View.Children. If the viewName is invalid, this function will return an error.
-func (self *View) Focus(viewName string) error {
-	if viewName == self.Name {
-		self.focusedview = viewName
-		return nil
-	}
-	for _, child := range self.Children {
-		if child.Name == viewName {
-			self.focusedview = viewName
-			return nil
-		}
+// AddChild nests child inside parent. child and its descendants take on the App
+// of parent, if it already has one.
+func (parent *View) AddChild(child *View) {
+	child.Parent = parent
+	parent.Children = append(parent.Children, child)
+	if app := parent.app(); app != nil {
+		child.setApp(app)
 	}
-	return fmt.Errorf("View with name '%s' does not exist", viewName)
 }
 
-// IsFocused returns a boolean value specifying whether the calling view has focus within
-// its parent.
-func (v *View) IsFocused() bool {
-	if v.Parent != nil && v.Name == v.Parent.focusedview {
-		return true
+// setApp sets the App of v and all of its descendants.
+func (v *View) setApp(app *App) {
+	v.App = app
+	for _, child := range v.Children {
+		child.setApp(app)
 	}
-
-	if v.App != nil && v.App.focusedView == v.Name {
-		return true
-	}
-
-	return false
-}
-
-func (self *View) FocusedView() string {
-	return self.focusedview
-}
-
-func (parent *View) AddChild(child *View) {
-	child.Parent = parent
-	parent.Children = append(parent.Children, child)
 }
 
 func (parent *View) GetView(name string) (view *View, ok bool) {
@@ -120,56 +103,46 @@ func (parent *View) GetView(name string) (view *View, ok bool) {
 type Keybind struct {
 	name        string
 	description string
-	key         tcell.Key
+	keys        []KeyChord
 	mode        Mode
-	callback    func(*View)
+	capture     bool
+	callback    func(*View) error
 }
 
+// cell is a position in the grid a view draws to. Cells which haven't been drawn
+// to have a zero char and show the view's fill.
 type cell struct {
-	x, y  int
 	char  rune
+	comb  []rune
 	style tcell.Style
 }
 
 func (kb *Keybind) String() string {
-	keyString := tcell.KeyNames[kb.key]
-	if keyString == "" {
-		keyString = string(kb.key)
-	}
-	if keyString == " " {
-		keyString = "<space>"
-	}
-
-	return fmt.Sprintf("%s - %s [%s]", keyString, kb.name, kb.description)
+	return fmt.Sprintf("%s - %s [%s]", KeysString(kb.keys), kb.name, kb.description)
 }
 
 func (kb *Keybind) Mode() Mode {
 	return kb.mode
 }
 
-func (v *View) getInnerBounds() (x1, y1, x2, y2 int) {
-	x1 = v.x + 1 + v.paddingl
-	y1 = v.y + 1 + v.paddingt
-	x2 = v.x + v.w - 2 - v.paddingr
-	y2 = v.y + v.h - 2 - v.paddingb
+// Keys returns the key sequence which triggers the keybind.
+func (kb *Keybind) Keys() []KeyChord {
+	return kb.keys
+}
 
-	if v.h == 1 {
-		y1 = v.y
-		y2 = v.y
-	}
-	if v.w == 1 {
-		x1 = v.x
-		x2 = v.x
+// getInnerBounds returns the screen rectangle inside the view's border and
+// padding. Views a single cell high or wide have no border or padding in that
+// direction.
+func (v *View) getInnerBounds() (x1, y1, x2, y2 int) {
+	x1, y1, x2, y2 = v.getOuterBounds()
+	if v.w > 1 {
+		x1 += 1 + v.paddingl
+		x2 -= 1 + v.paddingr
 	}
-
-	if v.Parent != nil {
-		px1, py1, px2, py2 := v.Parent.getInnerBounds()
-		x1 += px1
-		y1 += py1
-		x2 -= px2
-		y2 -= py2
+	if v.h > 1 {
+		y1 += 1 + v.paddingt
+		y2 -= 1 + v.paddingb
 	}
-
 	return x1, y1, x2, y2
 }
 
@@ -190,63 +163,132 @@ func (v *View) getOuterBounds() (x1, y1, x2, y2 int) {
 	return x1, y1, x2, y2
 }
 
-func (v *View) SetContent(x, y int, r rune, style tcell.Style) {
-	if x < 0 || x > v.w-1 {
-		return
+// getClipBounds returns the part of the view's outer rectangle which is on
+// screen, cut down to the inner rectangle of each of its ancestors. The
+// rectangle is empty, with x2 < x1 or y2 < y1, if the view is clipped entirely.
+func (v *View) getClipBounds() (x1, y1, x2, y2 int) {
+	x1, y1, x2, y2 = v.getOuterBounds()
+	for p := v.Parent; p != nil; p = p.Parent {
+		px1, py1, px2, py2 := p.getInnerBounds()
+		x1, y1 = max(x1, px1), max(y1, py1)
+		x2, y2 = min(x2, px2), min(y2, py2)
 	}
-	if y < 0 || y > v.h-1 {
+	return x1, y1, x2, y2
+}
+
+// SetContent draws r at x, y of the view's inner area, replacing whatever was
+// drawn there before. Positions outside the inner area are ignored.
+func (v *View) SetContent(x, y int, r rune, style tcell.Style) {
+	v.setCell(x, y, cell{char: r, style: style})
+}
+
+func (v *View) setCell(x, y int, c cell) {
+	if !v.inView(x, y) {
 		return
 	}
+	v.grid()[y*v.InnerWidth()+x] = c
+}
+
+func (v *View) inView(x, y int) bool {
+	return x >= 0 && x < v.InnerWidth() && y >= 0 && y < v.InnerHeight()
+}
 
-	v.cells = append(v.cells, cell{x: x, y: y, char: r, style: style})
+// grid returns the cells of the inner area, row by row, growing or shrinking
+// them to the view's current size.
+func (v *View) grid() []cell {
+	size := max(v.InnerWidth(), 0) * max(v.InnerHeight(), 0)
+	if len(v.cells) != size {
+		v.cells = make([]cell, size)
+	}
+	return v.cells
 }
 
+// SetTextContent draws text starting at column x of row y, cutting it off at the
+// inner edge of the view. Columns are screen columns, so a wide character moves
+// the text after it two columns along.
 func (v *View) SetTextContent(x, y int, text string, style tcell.Style) {
-	width := v.InnerWidth()
-	for xidx, t := range text {
-		if xidx < width {
-			v.SetContent(x+xidx, y, t, style)
+	v.drawText(x, y, text, v.InnerWidth()-x, style)
+}
+
+// drawText draws text starting at x, y using at most width columns. It returns
+// the number of columns drawn.
+func (v *View) drawText(x, y int, text string, width int, style tcell.Style) int {
+	col := 0
+	for _, cluster := range Graphemes(text) {
+		w := ClusterWidth(cluster)
+		if col+w > width {
+			break
 		}
+		v.setCluster(x+col, y, cluster, style)
+		col += w
 	}
+	return col
 }
 
+// setCluster draws a grapheme cluster at x, y. Zero width clusters, like a lone
+// combining mark, are dropped.
+func (v *View) setCluster(x, y int, cluster string, style tcell.Style) {
+	runes := []rune(cluster)
+	if ClusterWidth(cluster) == 0 {
+		return
+	}
+
+	v.setCell(x, y, cell{char: runes[0], comb: runes[1:], style: style})
+}
+
+// Clear removes everything drawn to the view, keeping the memory used for it.
 func (v *View) Clear() {
-	v.cells = []cell{}
+	clear(v.grid())
 }
 
+// Draw puts the view on screen, clipped to the inner area of its ancestors.
 func (v *View) Draw(screen tcell.Screen) {
 	x1, y1, _, _ := v.getInnerBounds()
 	bx1, by1, bx2, by2 := v.getOuterBounds()
+	cx1, cy1, cx2, cy2 := v.getClipBounds()
+	set := func(x, y int, r rune, comb []rune, style tcell.Style) {
+		if x >= cx1 && x <= cx2 && y >= cy1 && y <= cy2 {
+			screen.SetContent(x, y, r, comb, style)
+		}
+	}
 
-	// Draw fillColor
-	fillStyle := tcell.StyleDefault.Background(v.fillColor)
-	for yidx := by1; yidx <= by2; yidx++ {
-		for xidx := bx1; xidx <= bx2; xidx++ {
+	theme := v.Theme()
+
+	// Draw fill
+	fillStyle := theme.Style(v.fillRole)
+	for yidx := cy1; yidx <= cy2; yidx++ {
+		for xidx := cx1; xidx <= cx2; xidx++ {
 			screen.SetContent(xidx, yidx, ' ', nil, fillStyle)
 		}
 	}
 
-	borderStyle := tcell.StyleDefault.Background(v.fillColor).Foreground(v.borderColor)
+	borderRole := RoleBorder
+	if v.ContainsFocus() {
+		borderRole = RoleFocusedBorder
+	}
+	borderStyle := MergeStyles(fillStyle, theme.Style(borderRole))
 	if v.border && v.h > 2 {
-		screen.SetContent(bx1, by1, tcell.RuneULCorner, nil, borderStyle)
-		screen.SetContent(bx2, by1, tcell.RuneURCorner, nil, borderStyle)
-		screen.SetContent(bx1, by2, tcell.RuneLLCorner, nil, borderStyle)
-		screen.SetContent(bx2, by2, tcell.RuneLRCorner, nil, borderStyle)
+		set(bx1, by1, tcell.RuneULCorner, nil, borderStyle)
+		set(bx2, by1, tcell.RuneURCorner, nil, borderStyle)
+		set(bx1, by2, tcell.RuneLLCorner, nil, borderStyle)
+		set(bx2, by2, tcell.RuneLRCorner, nil, borderStyle)
 
 		for xidx := bx1 + 1; xidx < bx2; xidx++ {
-			screen.SetContent(xidx, by1, tcell.RuneHLine, nil, borderStyle)
-			screen.SetContent(xidx, by2, tcell.RuneHLine, nil, borderStyle)
+			set(xidx, by1, tcell.RuneHLine, nil, borderStyle)
+			set(xidx, by2, tcell.RuneHLine, nil, borderStyle)
 		}
 		for yidx := by1 + 1; yidx < by2; yidx++ {
-			screen.SetContent(bx1, yidx, tcell.RuneVLine, nil, borderStyle)
-			screen.SetContent(bx2, yidx, tcell.RuneVLine, nil, borderStyle)
+			set(bx1, yidx, tcell.RuneVLine, nil, borderStyle)
+			set(bx2, yidx, tcell.RuneVLine, nil, borderStyle)
 		}
 	}
 
-	for _, cell := range v.cells {
-		x := x1 + cell.x
-		y := y1 + cell.y
-		screen.SetContent(x, y, cell.char, nil, cell.style)
+	width := v.InnerWidth()
+	for idx, c := range v.grid() {
+		if c.char == 0 {
+			continue
+		}
+		set(x1+idx%width, y1+idx/width, c.char, c.comb, c.style)
 	}
 
 	if len(v.Children) > 0 {
@@ -254,76 +296,232 @@ func (v *View) Draw(screen tcell.Screen) {
 			if !child.visible {
 				continue
 			}
-			child.Clear()
-			child.renderFunc(child)
+			child.render()
 			child.Draw(screen)
 		}
 	}
 }
 
-func (v *View) Bind(mode Mode, key tcell.Key, name, description string, cb func(*View)) {
+// Bind binds keys, as parsed by ParseKeys, to cb while the view is in mode. keys
+// may be a sequence of chords which must be pressed one after the other, like
+// "g g" or "d d" in vim. Outside of InputMode, keys may be preceded by a numeric
+// count which the callback can read with View.Count. An error returned by cb is
+// shown in the status line, except for Propagate which passes the key on to the
+// view's parent.
+//
+// Bind panics if keys can't be parsed.
+func (v *View) Bind(mode Mode, keys string, name, description string, cb func(*View) error) {
+	v.BindKeys(mode, mustParseKeys(keys), name, description, cb)
+}
+
+// BindKeys is like Bind but takes an already parsed key sequence.
+func (v *View) BindKeys(mode Mode, keys []KeyChord, name, description string, cb func(*View) error) {
 	kb := Keybind{
 		name:        name,
 		description: description,
-		key:         key,
+		keys:        keys,
 		callback:    cb,
 		mode:        mode,
 	}
 	v.Keybinds = append(v.Keybinds, kb)
 }
 
-func (v *View) handleInputRune(r rune) {
-	if v.InputCursor == len(v.inputBuffer) {
-		v.inputBuffer = append(v.inputBuffer, r)
-	} else {
-		head := v.inputBuffer[:v.InputCursor]
-		tail := v.inputBuffer[v.InputCursor:]
-		v.inputBuffer = append(head, append([]rune{r}, tail...)...)
+// Count returns the numeric prefix typed before the keybind currently being
+// handled, or 1 if there was none.
+func (v *View) Count() int {
+	if v.count < 1 {
+		return 1
 	}
-	v.InputCursor++
+	return v.count
 }
 
-func (self *View) handleEvent(ev tcell.Event) {
-	if len(self.Children) > 0 {
-		if self.focusedview != self.Name {
-			focusedView, err := self.GetFocusedView()
-			if err != nil {
-				slog.Error("No focused child view", "error", err)
-			} else {
-				focusedView.handleEvent(ev)
-				return
-			}
-		}
+// HasCount reports whether a numeric prefix was typed before the keybind
+// currently being handled.
+func (v *View) HasCount() bool {
+	return v.count > 0
+}
+
+// PendingKeys returns the count and keys typed so far of an unfinished key
+// sequence, or an empty string if there is none.
+func (v *View) PendingKeys() string {
+	s := ""
+	if v.pendingCount > 0 {
+		s = strconv.Itoa(v.pendingCount)
 	}
+	return s + KeysString(v.pendingKeys)
+}
 
-	switch ev := ev.(type) {
-	case *tcell.EventKey:
-		var key tcell.Key
-		if ev.Key() == tcell.KeyRune {
-			if self.Mode == InputMode {
-				self.handleInputRune(ev.Rune())
-				return
-			}
-			key = tcell.Key(ev.Rune())
-		} else {
-			key = ev.Key()
-		}
-		kb, err := self.getKeybind(self.Mode, key)
-		if err == nil {
-			kb.callback(self)
+// handleEvent offers ev to the view during the target and bubble phases. count
+// is a count typed in a descendant before a key which bubbled up to the view. It
+// reports whether the view handled ev, or the count to pass on if it didn't.
+func (v *View) handleEvent(ev tcell.Event, count int) (bool, int) {
+	if v.component != nil && v.component.HandleEvent(v, ev) {
+		return true, 0
+	}
+
+	evKey, ok := ev.(*tcell.EventKey)
+	if !ok {
+		return false, count
+	}
+	key := NewKeyChord(evKey)
+	if v.Mode == InputMode && len(v.pendingKeys) == 0 && (key.isPlainRune() || !v.hasKeybind(key)) {
+		return v.Input.HandleKey(key), count
+	}
+	return v.handleKey(key, count)
+}
+
+// capture offers key to the view's capture keybinds on its way down to the
+// focused view below it. It reports whether a keybind handled key.
+func (v *View) capture(key KeyChord) bool {
+	for _, kb := range v.Keybinds {
+		if kb.capture && kb.mode == v.Mode && slices.Equal(kb.keys, []KeyChord{key}) {
+			return v.runKeybind(kb, 0)
 		}
 	}
+	return false
+}
+
+// handleKey feeds key into the pending key sequence, calling the matching
+// keybind once the sequence is complete. A key which neither starts nor finishes
+// a keybind isn't handled, and is returned to bubble up with the count typed
+// before it.
+func (v *View) handleKey(key KeyChord, count int) (bool, int) {
+	if count > 0 && len(v.pendingKeys) == 0 {
+		v.pendingCount = count
+	}
+
+	if v.Mode != InputMode && len(v.pendingKeys) == 0 && v.isCountKey(key) {
+		v.pendingCount = min(v.pendingCount*10+int(key.Rune-'0'), MaxCount)
+		v.waitForKeys()
+		return true, 0
+	}
+
+	keys := append(slices.Clone(v.pendingKeys), key)
+	if v.hasLongerKeybind(v.Mode, keys) {
+		v.pendingKeys = keys
+		v.waitForKeys()
+		return true, 0
+	}
+
+	if _, err := v.getKeybind(v.Mode, keys); err != nil && len(v.pendingKeys) == 0 {
+		count := v.pendingCount
+		v.resetPending()
+		return false, count
+	}
+
+	v.pendingKeys = keys
+	return v.finishSequence()
+}
+
+func (v *View) isCountKey(key KeyChord) bool {
+	if !key.isPlainRune() || key.Rune < '0' || key.Rune > '9' || (key.Rune == '0' && v.pendingCount == 0) {
+		return false
+	}
+	_, err := v.getKeybind(v.Mode, []KeyChord{key})
+	return err != nil
+}
+
+// finishSequence calls the keybind matching the pending keys, if any, and resets
+// the pending sequence. A sequence matching no keybind is dropped. If the
+// keybind returns Propagate, the sequence isn't handled and the count is
+// returned to be passed on.
+func (v *View) finishSequence() (bool, int) {
+	keys := v.pendingKeys
+	count := v.pendingCount
+	v.resetPending()
+
+	kb, err := v.getKeybind(v.Mode, keys)
+	if err != nil {
+		return true, 0
+	}
+	if !v.runKeybind(kb, count) {
+		return false, count
+	}
+	return true, 0
+}
+
+// runKeybind calls kb's callback with count, showing any error it returns in
+// the status line. It reports false if the callback returned Propagate.
+func (v *View) runKeybind(kb Keybind, count int) bool {
+	v.count = count
+	err := kb.callback(v)
+	v.count = 0
+	if errors.Is(err, Propagate) {
+		return false
+	}
+	if app := v.app(); app != nil {
+		app.reportError(err)
+	}
+	return true
+}
+
+func (v *View) resetPending() {
+	v.pendingKeys = nil
+	v.pendingCount = 0
+	v.pendingSeq++
+}
+
+// waitForKeys schedules the pending sequence to finish if no more keys are
+// pressed within SequenceTimeout.
+func (v *View) waitForKeys() {
+	v.pendingSeq++
+	app := v.app()
+	if app == nil {
+		return
+	}
+
+	seq := v.pendingSeq
+	app.After(SequenceTimeout, func(*App) {
+		v.handleSequenceTimeout(seq)
+	})
+}
+
+func (v *View) handleSequenceTimeout(seq int) {
+	if seq != v.pendingSeq {
+		return
+	}
+	v.finishSequence()
+	invalidatePath(v)
+	if app := v.app(); app != nil {
+		app.inputState.Changed()
+	}
 }
 
-func (v *View) getKeybind(m Mode, key tcell.Key) (Keybind, error) {
+// app returns the App the view, or its top level ancestor, was added to.
+func (v *View) app() *App {
+	for v.App == nil && v.Parent != nil {
+		v = v.Parent
+	}
+	return v.App
+}
+
+func (v *View) getKeybind(m Mode, keys []KeyChord) (Keybind, error) {
 	for _, kb := range v.Keybinds {
-		if kb.mode == m && kb.key == key {
+		if kb.mode == m && slices.Equal(kb.keys, keys) {
 			return kb, nil
 		}
 	}
 	return Keybind{}, errors.New("Keybind does not exist")
 }
 
+// hasKeybind reports whether key is bound in the current mode, on its own or as
+// the start of a sequence.
+func (v *View) hasKeybind(key KeyChord) bool {
+	keys := []KeyChord{key}
+	_, err := v.getKeybind(v.Mode, keys)
+	return err == nil || v.hasLongerKeybind(v.Mode, keys)
+}
+
+// hasLongerKeybind reports whether keys is the start of a longer bound sequence.
+func (v *View) hasLongerKeybind(m Mode, keys []KeyChord) bool {
+	for _, kb := range v.Keybinds {
+		if kb.mode == m && len(kb.keys) > len(keys) && slices.Equal(kb.keys[:len(keys)], keys) {
+			return true
+		}
+	}
+	return false
+}
+
 func (v *View) SetPadding(t, r, b, l int) {
 	v.paddingt = t
 	v.paddingr = r
@@ -339,48 +537,61 @@ func (v *View) Height() int {
 	return v.h
 }
 
+// InnerWidth returns the number of columns inside the view's border and padding.
 func (v *View) InnerWidth() int {
-	return v.w - v.paddingl - v.paddingr - 2
+	x1, _, x2, _ := v.getInnerBounds()
+	return x2 - x1 + 1
 }
 
+// InnerHeight returns the number of rows inside the view's border and padding.
 func (v *View) InnerHeight() int {
-	return v.h - v.paddingt - v.paddingb
+	_, y1, _, y2 := v.getInnerBounds()
+	return y2 - y1 + 1
 }
 
 func (v *View) ShowCursor() {
-	x1, y1, _, _ := v.getInnerBounds()
-	v.App.screen.ShowCursor(x1+v.Cursorx, y1+v.Cursory)
-}
-
-func (v *View) HideCursor() {
-	v.App.screen.HideCursor()
-}
-
-func (v *View) GetInputBuffer() []rune {
-	return v.inputBuffer
+	v.ShowCursorAt(v.Cursorx, v.Cursory)
 }
 
-func (v *View) SetInputBuffer(runes []rune) {
-	v.inputBuffer = runes
+// ShowCursorAt shows the terminal cursor at x, y relative to the view's inner
+// area.
+func (v *View) ShowCursorAt(x, y int) {
+	x1, y1, _, _ := v.getInnerBounds()
+	v.app().screen.ShowCursor(x1+x, y1+y)
 }
 
-func (v *View) ClearInputBuffer() {
-	v.inputBuffer = []rune{}
-	v.InputCursor = 0
+func (v *View) HideCursor() {
+	v.app().screen.HideCursor()
 }
 
-func (v *View) SetFillColor(color tcell.Color) {
-	v.fillColor = color
+func (v *View) SetFillRole(role Role) {
+	v.fillRole = role
 }
 
-func (v *View) SetBorderColor(color tcell.Color) {
-	v.borderColor = color
+// Theme returns the theme of the App the view belongs to.
+func (v *View) Theme() *Theme {
+	app := v.app()
+	if app == nil {
+		return DefaultTheme()
+	}
+	return app.Theme()
 }
 
 func (v *View) Show() {
-	v.visible = true
+	v.setVisible(true)
 }
 
 func (v *View) Hide() {
-	v.visible = false
+	v.setVisible(false)
+}
+
+func (v *View) setVisible(visible bool) {
+	if v.visible == visible {
+		return
+	}
+	v.visible = visible
+	v.dirty = true
+	if app := v.app(); app != nil {
+		app.invalidateLayout()
+	}
 }
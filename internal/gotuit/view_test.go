@@ -0,0 +1,87 @@
+package gotuit
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestChildClippedToParent(t *testing.T) {
+	app, screen := newTestApp(t, 40, 30)
+	parent := NewView("Parent", 0, 0, 20, 10, func(*View) {})
+	parent.SetPadding(1, 1, 1, 1)
+	child := NewView("Child", 0, 0, 30, 25, func(v *View) {
+		for y := range v.InnerHeight() {
+			for x := range v.InnerWidth() {
+				v.SetContent(x, y, 'x', tcell.StyleDefault)
+			}
+		}
+	})
+	parent.AddChild(child)
+	app.AddView(parent)
+	app.Draw()
+
+	// The parent's inner rect is inside its border and a cell of padding.
+	ix1, iy1, ix2, iy2 := 2, 2, 17, 7
+	if x1, y1, x2, y2 := parent.getInnerBounds(); x1 != ix1 || y1 != iy1 || x2 != ix2 || y2 != iy2 {
+		t.Fatalf("Parent inner rect is %d,%d-%d,%d, want %d,%d-%d,%d", x1, y1, x2, y2, ix1, iy1, ix2, iy2)
+	}
+
+	w, h := screen.Size()
+	for y := range h {
+		for x := range w {
+			r, _, _, _ := screen.GetContent(x, y)
+			inside := x >= ix1 && x <= ix2 && y >= iy1 && y <= iy2
+			if r == 'x' && !inside {
+				t.Errorf("Child drew at %d,%d, outside the parent's inner rect", x, y)
+			}
+			// The child's top left corner is at the parent's inner origin and
+			// the rest of the inner rect is its content.
+			if inside && x > ix1 && y > iy1 && r != 'x' {
+				t.Errorf("Got '%c' at %d,%d, want the child's content", r, x, y)
+			}
+		}
+	}
+
+	for _, c := range []struct {
+		x, y int
+		want rune
+	}{
+		{0, 0, tcell.RuneULCorner},
+		{19, 9, tcell.RuneLRCorner},
+		{1, 1, ' '},
+		{18, 8, ' '},
+		{ix1, iy1, tcell.RuneULCorner},
+	} {
+		if r, _, _, _ := screen.GetContent(c.x, c.y); r != c.want {
+			t.Errorf("Got '%c' at %d,%d, want '%c'", r, c.x, c.y, c.want)
+		}
+	}
+}
+
+func TestSetContentTwiceKeepsLast(t *testing.T) {
+	app, screen := newTestApp(t, 20, 10)
+	v := NewView("View", 0, 0, 10, 5, func(v *View) {
+		v.SetContent(1, 1, 'a', tcell.StyleDefault)
+		v.SetContent(1, 1, 'b', tcell.StyleDefault)
+	})
+	app.AddView(v)
+	app.Draw()
+
+	grid := v.grid()
+	if len(grid) != v.InnerWidth()*v.InnerHeight() {
+		t.Fatalf("Grid has %d cells, want %d", len(grid), v.InnerWidth()*v.InnerHeight())
+	}
+	drawn := 0
+	for _, c := range grid {
+		if c.char != 0 {
+			drawn++
+		}
+	}
+	if drawn != 1 {
+		t.Errorf("Grid has %d cells drawn, want 1", drawn)
+	}
+	if r, _, _, _ := screen.GetContent(2, 2); r != 'b' {
+		t.Errorf("Got '%c' on screen, want 'b'", r)
+	}
+}
@@ -0,0 +1,300 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/FFX01/gettuit/internal/gotuit"
+)
+
+// Action is an operation a user can bind keys to. Actions are registered under an
+// id which stays stable so it can be referenced from the keymap config.
+type Action struct {
+	ID          string
+	Keys        []string
+	Name        string
+	Description string
+	Callback    func(*gotuit.View) error
+}
+
+// GlobalAction is an Action bound on the App rather than on a view.
+type GlobalAction struct {
+	ID          string
+	Keys        []string
+	Name        string
+	Description string
+	Callback    func(*gotuit.App) error
+}
+
+// ViewActions are the actions available in one view while it is in one mode.
+type ViewActions struct {
+	View    string
+	Mode    gotuit.Mode
+	Actions []Action
+}
+
+type binding struct {
+	keys   []gotuit.KeyChord
+	action Action
+}
+
+type globalBinding struct {
+	key    gotuit.KeyChord
+	action GlobalAction
+}
+
+// Keymap is the validated set of keybinds for every view.
+type Keymap struct {
+	views  map[string][]viewBindings
+	global []globalBinding
+}
+
+type viewBindings struct {
+	mode     gotuit.Mode
+	bindings []binding
+}
+
+func (m *Model) actions() []ViewActions {
+	return []ViewActions{
+		{View: "Saved Views", Mode: gotuit.NormalMode, Actions: []Action{
+			{"cursor-up", []string{"k", "Up"}, "Up", "Move cursor up", m.onSavedViewsCursorUp},
+			{"cursor-down", []string{"j", "Down"}, "Down", "Move cursor down", m.onSavedViewsCursorDown},
+			{"select", []string{"Enter"}, "Select", "Show todos matching view", m.onSavedViewsSelect},
+			{"delete", []string{"D"}, "[D]elete View", "Delete saved view on cursor", m.onSavedViewsDelete},
+			{"exit", []string{"Esc"}, "Exit", "Return to todo list", m.onSavedViewsExit},
+		}},
+		{View: "Todo List", Mode: gotuit.NormalMode, Actions: []Action{
+			{"cursor-up", []string{"k", "Up"}, "Up", "Move cursor up", m.onTodoListCursorUp},
+			{"cursor-down", []string{"j", "Down"}, "Down", "Move cursor down", m.onTodoListCursorDown},
+			{"move-up", []string{"C-k"}, "Move Up", "Move item up", m.onTodoListMoveTodoUp},
+			{"move-down", []string{"C-j"}, "Move Down", "Move item down", m.onTodoListMoveTodoDown},
+			{"toggle-complete", []string{"Space"}, "Toggle Complete", "Toggle completion status", m.onTodoListToggleComplete},
+			{"add", []string{"a"}, "[A]dd Todo", "Add a new todo", m.onTodoListAddTodo},
+			{"delete", []string{"D", "d d"}, "[D]elete Todo", "Delete [count] todos from cursor", m.onTodoListDeleteTodo},
+			{"edit", []string{"e"}, "[E]dit Todo", "Edit todo on cursor", m.onTodoListEditTodo},
+			{"replace", []string{"r"}, "[R]eplace Todo", "Replace todo with a new one", m.onTodoListReplaceTodo},
+			{"jump-top", []string{"C-u", "g g"}, "Jump to top", "Jump to top, or to todo [count]", m.onTodoListJumpToTop},
+			{"jump-bottom", []string{"C-d", "G"}, "Jump to bottom", "Jump to bottom, or to todo [count]", m.onTodoListJumpToBottom},
+			{"yank", []string{"y y"}, "Yank Todo", "Copy [count] todos from cursor", m.onTodoListYankTodo},
+			{"paste", []string{"p"}, "[P]aste", "Paste yanked todos below cursor", m.onTodoListPasteTodo},
+			{"search", []string{"/"}, "Search", "Enter search mode", m.onEnterSearchMode},
+			{"filter", []string{"f"}, "[F]ilter", "Only show todos matching a query", m.onEnterFilterMode},
+			{"clear-filter", []string{"F"}, "Clear [F]ilter", "Show all todos", m.onTodoListClearFilter},
+			{"saved-views", []string{"s"}, "[S]aved Views", "Focus saved views", m.onTodoListFocusSavedViews},
+			{"save-view", []string{"S"}, "[S]ave View", "Save filter as a named view", m.onTodoListSaveView},
+			{"cycle-sort", []string{"o"}, "Sort [O]rder", "Cycle through sort modes", m.onTodoListCycleSort},
+			{"manual-sort", []string{"O"}, "Manual [O]rder", "Return to manual sort", m.onTodoListManualSort},
+			{"toggle-wrap", []string{"w"}, "[W]rap", "Toggle wrapping of long todos", m.onTodoListToggleWrap},
+			{"next-match", []string{"n"}, "Next", "Next Search Match", m.onNextSearchMatch},
+			{"previous-match", []string{"N"}, "Previous", "Previous search match", m.onPreviousSearchMatch},
+			{"toggle-focus", nil, "Focus Next", "Move focus to the next view", m.onToggleFocus},
+			{"clear-search", []string{"Esc"}, "Exit Search", "Exit search and clear results", m.onTodoListEscape},
+			{"undo", []string{"u"}, "[U]ndo", "Undo last change", m.onTodoListUndo},
+			{"visual-mode", []string{"V"}, "[V]isual Mode", "Select a range of todos", m.onTodoListVisualMode},
+			{"mark", []string{"m"}, "[M]ark", "Select todo on cursor", m.onTodoListMark},
+		}},
+		{View: "Todo List", Mode: gotuit.VisualMode, Actions: []Action{
+			{"cursor-up", []string{"k", "Up"}, "Up", "Move cursor up", m.onTodoListCursorUp},
+			{"cursor-down", []string{"j", "Down"}, "Down", "Move cursor down", m.onTodoListCursorDown},
+			{"mark", []string{"m"}, "[M]ark", "Toggle selection of todo on cursor", m.onTodoListMark},
+			{"toggle-range", []string{"V"}, "[V]isual Range", "Start or end a selection range", m.onVisualToggleRange},
+			{"toggle-complete", []string{"Space"}, "Toggle Complete", "Toggle completion of selection", m.onVisualToggleComplete},
+			{"delete", []string{"D"}, "[D]elete", "Delete selected todos", m.onVisualDelete},
+			{"move-up", []string{"C-k"}, "Move Up", "Move selection up", m.onVisualMoveUp},
+			{"move-down", []string{"C-j"}, "Move Down", "Move selection down", m.onVisualMoveDown},
+			{"tag", []string{"t"}, "[T]ag", "Tag selected todos", m.onVisualTag},
+			{"priority", []string{"p"}, "[P]riority", "Set priority of selected todos", m.onVisualPriority},
+			{"exit", []string{"Esc"}, "Exit", "Clear selection", m.onVisualExit},
+		}},
+		{View: "Todo List", Mode: gotuit.InputMode, Actions: []Action{
+			{"confirm", []string{"Enter"}, "Confirm", "Confirm changes", m.onTodoListConfirmTodo},
+			{"cancel", []string{"Esc"}, "Exit", "Cancel Changes", m.onTodoListInputEscape},
+		}},
+		{View: "Test Child", Mode: gotuit.NormalMode, Actions: []Action{
+			{"toggle-focus", nil, "Focus Next", "Move focus to the next view", m.onToggleFocus},
+		}},
+		{View: "Help Modal", Mode: gotuit.NormalMode, Actions: []Action{
+			{"exit", []string{"Esc"}, "Exit", "Exit Help", m.onHelpExit},
+		}},
+		{View: "Log Panel", Mode: gotuit.NormalMode, Actions: m.logPanel.actions()},
+		{View: "Search Line", Mode: gotuit.InputMode, Actions: []Action{
+			{"exit", []string{"Esc"}, "Exit", "Exit search mode", onExitSearchMode},
+			{"confirm", []string{"Enter"}, "Confirm", "Confirm search", m.onSearchConfirm},
+		}},
+	}
+}
+
+func (m *Model) globalActions() []GlobalAction {
+	return []GlobalAction{
+		{"quit", []string{"C-c"}, "Quit", "Quit program", m.onGlobalQuit},
+		{"help", []string{"F1"}, "Help", "Show Help", m.onGlobalShowHelp},
+		{"toggle-log", []string{"F2"}, "Log", "Show or hide the log panel", m.onGlobalToggleLogPanel},
+		{"cycle-theme", []string{"F5"}, "Theme", "Switch to the next theme", m.onGlobalCycleTheme},
+	}
+}
+
+func parseMode(name string) (gotuit.Mode, bool) {
+	for mode, modeName := range modeMap {
+		if strings.EqualFold(modeName, name) {
+			return mode, true
+		}
+	}
+	return gotuit.NormalMode, false
+}
+
+// newKeymap combines the default keys of every action with the overrides from
+// config. Keys set in config replace all of the default keys of an action. Every
+// problem found, like unknown actions, unparsable keys or two actions sharing the
+// same keys, is reported in the returned error.
+func newKeymap(config Config, views []ViewActions, global []GlobalAction) (*Keymap, error) {
+	km := Keymap{views: map[string][]viewBindings{}}
+	errs := []error{}
+
+	globalKeys := map[gotuit.KeyChord]string{}
+	globalIDs := map[string]bool{}
+	for _, action := range global {
+		globalIDs[action.ID] = true
+		keys := action.Keys
+		if override, ok := config.GlobalKeymap[action.ID]; ok {
+			keys = override
+		}
+		for _, s := range keys {
+			kc, err := gotuit.ParseKeyChord(s)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("global %s: %w", action.ID, err))
+				continue
+			}
+			if other, ok := globalKeys[kc]; ok {
+				errs = append(errs, fmt.Errorf("global: '%s' is bound to both %s and %s", kc, other, action.ID))
+				continue
+			}
+			globalKeys[kc] = action.ID
+			km.global = append(km.global, globalBinding{key: kc, action: action})
+		}
+	}
+	for _, id := range slices.Sorted(maps.Keys(config.GlobalKeymap)) {
+		if !globalIDs[id] {
+			errs = append(errs, fmt.Errorf("global: unknown action '%s'", id))
+		}
+	}
+
+	known := map[string]map[gotuit.Mode]map[string]bool{}
+	for _, va := range views {
+		overrides := map[string][]string{}
+		if modes, ok := config.Keymap[va.View]; ok {
+			for modeName, actions := range modes {
+				if mode, ok := parseMode(modeName); ok && mode == va.Mode {
+					overrides = actions
+				}
+			}
+		}
+
+		if known[va.View] == nil {
+			known[va.View] = map[gotuit.Mode]map[string]bool{}
+		}
+		known[va.View][va.Mode] = map[string]bool{}
+
+		vb := viewBindings{mode: va.Mode}
+		bound := map[string]string{}
+		for _, action := range va.Actions {
+			known[va.View][va.Mode][action.ID] = true
+			keys := action.Keys
+			if override, ok := overrides[action.ID]; ok {
+				keys = override
+			}
+
+			for _, s := range keys {
+				chords, err := gotuit.ParseKeys(s)
+				if err != nil {
+					errs = append(errs, fmt.Errorf("%s %s %s: %w", va.View, modeMap[va.Mode], action.ID, err))
+					continue
+				}
+				keyString := gotuit.KeysString(chords)
+				if other, ok := bound[keyString]; ok {
+					errs = append(errs, fmt.Errorf("%s %s: '%s' is bound to both %s and %s", va.View, modeMap[va.Mode], keyString, other, action.ID))
+					continue
+				}
+				if other, ok := globalKeys[chords[0]]; ok {
+					errs = append(errs, fmt.Errorf("%s %s: '%s' of %s is shadowed by global %s", va.View, modeMap[va.Mode], keyString, action.ID, other))
+					continue
+				}
+				bound[keyString] = action.ID
+				vb.bindings = append(vb.bindings, binding{keys: chords, action: action})
+			}
+		}
+		errs = append(errs, prefixConflicts(va, vb.bindings)...)
+		km.views[va.View] = append(km.views[va.View], vb)
+	}
+
+	for _, view := range slices.Sorted(maps.Keys(config.Keymap)) {
+		if known[view] == nil {
+			errs = append(errs, fmt.Errorf("Unknown view '%s'", view))
+			continue
+		}
+		modes := config.Keymap[view]
+		for _, modeName := range slices.Sorted(maps.Keys(modes)) {
+			mode, ok := parseMode(modeName)
+			if !ok || known[view][mode] == nil {
+				errs = append(errs, fmt.Errorf("%s: unknown mode '%s'", view, modeName))
+				continue
+			}
+			for _, id := range slices.Sorted(maps.Keys(modes[modeName])) {
+				if !known[view][mode][id] {
+					errs = append(errs, fmt.Errorf("%s %s: unknown action '%s'", view, modeMap[mode], id))
+				}
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return &km, nil
+}
+
+// prefixConflicts reports every binding whose keys start the sequence of another
+// binding in the same view and mode. The shorter keys would only fire after
+// gotuit.SequenceTimeout, waiting for the rest of the longer sequence.
+func prefixConflicts(va ViewActions, bindings []binding) []error {
+	errs := []error{}
+	for _, short := range bindings {
+		for _, long := range bindings {
+			if len(short.keys) < len(long.keys) && slices.Equal(long.keys[:len(short.keys)], short.keys) {
+				errs = append(errs, fmt.Errorf("%s %s: '%s' of %s is the start of '%s' of %s", va.View, modeMap[va.Mode],
+					gotuit.KeysString(short.keys), short.action.ID, gotuit.KeysString(long.keys), long.action.ID))
+			}
+		}
+	}
+	return errs
+}
+
+// Apply binds the keys of every action registered for v.
+func (km *Keymap) Apply(v *gotuit.View) {
+	for _, vb := range km.views[v.Name] {
+		for _, b := range vb.bindings {
+			v.BindKeys(vb.mode, b.keys, b.action.Name, b.action.Description, b.action.Callback)
+		}
+	}
+}
+
+// Bindings returns the keys bound to each action of view in mode, for
+// components which handle their keys themselves.
+func (km *Keymap) Bindings(view string, mode gotuit.Mode) []binding {
+	bindings := []binding{}
+	for _, vb := range km.views[view] {
+		if vb.mode == mode {
+			bindings = append(bindings, vb.bindings...)
+		}
+	}
+	return bindings
+}
+
+// ApplyGlobal binds the keys of every global action on app.
+func (km *Keymap) ApplyGlobal(app *gotuit.App) {
+	for _, b := range km.global {
+		app.BindChord(b.key, b.action.Name, b.action.Description, b.action.Callback)
+	}
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewKeymapConflicts(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		keymap  map[string][]string
+		wantErr string
+	}{
+		{"defaults", nil, ""},
+		{"override", map[string][]string{"delete": {"x"}}, ""},
+		{"same keys", map[string][]string{"add": {"e"}}, "'e' is bound to both"},
+		{"prefix", map[string][]string{"toggle-complete": {"d"}}, "'d' of toggle-complete is the start of 'd d' of delete"},
+		{"prefix within action", map[string][]string{"delete": {"d", "d d"}}, "'d' of delete is the start of 'd d' of delete"},
+		{"unknown action", map[string][]string{"fly": {"f"}}, "unknown action 'fly'"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			model := &Model{logPanel: &logPanel{}}
+			config := Config{}
+			if tc.keymap != nil {
+				config.Keymap = map[string]map[string]map[string][]string{"Todo List": {"normal": tc.keymap}}
+			}
+			_, err := newKeymap(config, model.actions(), model.globalActions())
+			switch {
+			case tc.wantErr == "" && err != nil:
+				t.Fatalf("Unexpected error: %s", err)
+			case tc.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tc.wantErr)):
+				t.Fatalf("Got error %v, want one containing %q", err, tc.wantErr)
+			}
+		})
+	}
+}
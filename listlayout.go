@@ -0,0 +1,133 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/FFX01/gettuit/internal/gotuit"
+)
+
+// todoIndent is the width of the "[ ] " prefix in front of todo text. Wrapped
+// lines are indented by the same amount.
+const todoIndent = len("[ ] ")
+
+// todoLine is a screen line of the "Todo List" view, showing the bytes start:end
+// of the text of the todo at row.
+type todoLine struct {
+	row        int
+	start, end int
+	ellipsis   bool
+}
+
+// listRows returns the number of lines that fit in the "Todo List" view. The
+// bottom lines are covered by the "Test Child" view.
+func listRows(v *gotuit.View) int {
+	return max(v.InnerHeight()-3, 1)
+}
+
+// layoutTodos returns the lines every visible todo is drawn on, indexed by row.
+// Todos take up a single truncated line unless wrapping is on. The todo being
+// edited always takes a single line, its input scrolls instead.
+func (m *Model) layoutTodos(v *gotuit.View) [][]todoLine {
+	width := v.InnerWidth() - todoIndent
+	visible := m.visibleTodos()
+	layout := make([][]todoLine, len(visible))
+	for row, idx := range visible {
+		text := m.todos[idx].text
+		switch {
+		case m.todos[idx].temp:
+			layout[row] = []todoLine{{row: row}}
+		case m.wrap:
+			layout[row] = wrappedLines(row, text, width)
+		default:
+			truncated := strings.TrimSuffix(gotuit.Truncate(text, width), gotuit.Ellipsis)
+			layout[row] = []todoLine{{row: row, end: len(truncated), ellipsis: len(truncated) < len(text)}}
+		}
+	}
+	return layout
+}
+
+// wrappedLines maps the lines text wraps into back onto byte ranges of text.
+func wrappedLines(row int, text string, width int) []todoLine {
+	lines := []todoLine{}
+	pos := 0
+	for _, line := range gotuit.Wrap(text, width) {
+		start := pos + strings.Index(text[pos:], line)
+		pos = start + len(line)
+		lines = append(lines, todoLine{row: row, start: start, end: pos})
+	}
+	return lines
+}
+
+// lineCount returns the number of lines taken by the todos from row first up to
+// and including row last.
+func lineCount(layout [][]todoLine, first, last int) int {
+	count := 0
+	for _, lines := range layout[first : last+1] {
+		count += len(lines)
+	}
+	return count
+}
+
+// lastVisibleRow returns the last row which fits on screen entirely.
+func (m *Model) lastVisibleRow(layout [][]todoLine, rows int) int {
+	last := m.listOffset
+	for last+1 < len(layout) && lineCount(layout, m.listOffset, last+1) <= rows {
+		last++
+	}
+	return last
+}
+
+// clampOffset keeps the scroll offset from leaving empty lines at the bottom of
+// the "Todo List" view while there are todos above the screen.
+func (m *Model) clampOffset(layout [][]todoLine, rows int) {
+	m.listOffset = max(min(m.listOffset, len(layout)-1), 0)
+	for m.listOffset > 0 && lineCount(layout, m.listOffset-1, len(layout)-1) <= rows {
+		m.listOffset--
+	}
+}
+
+// scrollToCursor moves the scroll offset of the "Todo List" view so the todo on
+// the cursor is on screen.
+func (m *Model) scrollToCursor(v *gotuit.View, layout [][]todoLine) {
+	if len(layout) == 0 {
+		m.listOffset = 0
+		return
+	}
+	rows := listRows(v)
+	m.listOffset = min(m.listOffset, v.Cursory)
+	for m.listOffset < v.Cursory && lineCount(layout, m.listOffset, v.Cursory) > rows {
+		m.listOffset++
+	}
+	m.clampOffset(layout, rows)
+}
+
+// screenLines returns the lines drawn on screen, starting at the scroll offset.
+func (m *Model) screenLines(v *gotuit.View, layout [][]todoLine) []todoLine {
+	rows := listRows(v)
+	lines := []todoLine{}
+	for _, todoLines := range layout[min(m.listOffset, len(layout)):] {
+		for _, line := range todoLines {
+			if len(lines) == rows {
+				return lines
+			}
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// rowAt returns the row of the todo drawn on screen line y of the "Todo List"
+// view.
+func (m *Model) rowAt(v *gotuit.View, y int) (int, bool) {
+	lines := m.screenLines(v, m.layoutTodos(v))
+	if y < 0 || y >= len(lines) {
+		return 0, false
+	}
+	return lines[y].row, true
+}
+
+func (m *Model) onTodoListToggleWrap(v *gotuit.View) error {
+	m.wrap = !m.wrap
+	m.state.Changed()
+	return nil
+}
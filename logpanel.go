@@ -0,0 +1,337 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/FFX01/gettuit/internal/gotuit"
+	"github.com/gdamore/tcell/v2"
+)
+
+// logHistorySize is the number of log records kept for the "Log Panel" view.
+const logHistorySize = 1000
+
+// logLevels are the levels the "Log Panel" view can be filtered to, in the order
+// they are cycled through.
+var logLevels = []slog.Level{slog.LevelDebug, slog.LevelInfo, slog.LevelWarn, slog.LevelError}
+
+// logEntry is a log record formatted for the "Log Panel" view.
+type logEntry struct {
+	time    time.Time
+	level   slog.Level
+	message string
+	// attrs holds the record's attributes as space separated key=value pairs.
+	attrs string
+}
+
+// logBuffer keeps the most recent log records. It is safe to use from any
+// goroutine.
+type logBuffer struct {
+	mu      sync.Mutex
+	entries []logEntry
+}
+
+func (b *logBuffer) add(entry logEntry) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.entries = append(b.entries, entry)
+	if len(b.entries) > logHistorySize {
+		b.entries = slices.Delete(b.entries, 0, len(b.entries)-logHistorySize)
+	}
+}
+
+// filter returns the entries of level or above, oldest first.
+func (b *logBuffer) filter(level slog.Level) []logEntry {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	entries := []logEntry{}
+	for _, entry := range b.entries {
+		if entry.level >= level {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// bufferHandler is a slog.Handler recording every record in a logBuffer. With
+// app set, it also passes each record on to notify, so views showing the buffer
+// are redrawn.
+type bufferHandler struct {
+	buffer *logBuffer
+	app    *gotuit.App
+	notify *logNotifier
+	attrs  string
+	group  string
+}
+
+// logNotifier marks a State changed whenever records are added, posting at most
+// one event at a time so a burst of records doesn't fill the event queue.
+type logNotifier struct {
+	state   gotuit.State
+	posting atomic.Bool
+}
+
+func (n *logNotifier) changed(app *gotuit.App) {
+	if !n.posting.CompareAndSwap(false, true) {
+		return
+	}
+	err := app.Post(func(*gotuit.App) {
+		n.posting.Store(false)
+		n.state.Changed()
+	})
+	if err != nil {
+		n.posting.Store(false)
+	}
+}
+
+func (h *bufferHandler) Enabled(context.Context, slog.Level) bool {
+	return true
+}
+
+func (h *bufferHandler) Handle(_ context.Context, r slog.Record) error {
+	attrs := h.attrs
+	r.Attrs(func(a slog.Attr) bool {
+		attrs += h.format(a)
+		return true
+	})
+	h.buffer.add(logEntry{
+		time:    r.Time,
+		level:   r.Level,
+		message: r.Message,
+		attrs:   strings.TrimSpace(attrs),
+	})
+	if h.app != nil {
+		h.notify.changed(h.app)
+	}
+	return nil
+}
+
+func (h *bufferHandler) format(a slog.Attr) string {
+	key := a.Key
+	if h.group != "" {
+		key = h.group + "." + key
+	}
+	return fmt.Sprintf(" %s=%v", key, a.Value)
+}
+
+func (h *bufferHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := *h
+	for _, a := range attrs {
+		clone.attrs += h.format(a)
+	}
+	return &clone
+}
+
+func (h *bufferHandler) WithGroup(name string) slog.Handler {
+	clone := *h
+	if h.group != "" {
+		name = h.group + "." + name
+	}
+	clone.group = name
+	return &clone
+}
+
+// teeHandler passes records on to every handler which is enabled for them.
+type teeHandler []slog.Handler
+
+func (t teeHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, h := range t {
+		if h.Enabled(ctx, level) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t teeHandler) Handle(ctx context.Context, r slog.Record) error {
+	var errs []error
+	for _, h := range t {
+		if h.Enabled(ctx, r.Level) {
+			errs = append(errs, h.Handle(ctx, r.Clone()))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (t teeHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handlers := teeHandler{}
+	for _, h := range t {
+		handlers = append(handlers, h.WithAttrs(attrs))
+	}
+	return handlers
+}
+
+func (t teeHandler) WithGroup(name string) slog.Handler {
+	handlers := teeHandler{}
+	for _, h := range t {
+		handlers = append(handlers, h.WithGroup(name))
+	}
+	return handlers
+}
+
+// logSeverity maps a log level to the status severity used to color it.
+func logSeverity(level slog.Level) gotuit.Severity {
+	switch {
+	case level >= slog.LevelError:
+		return gotuit.SeverityError
+	case level >= slog.LevelWarn:
+		return gotuit.SeverityWarn
+	}
+	return gotuit.SeverityInfo
+}
+
+// logPanel is the component of the "Log Panel" view. It lists the log records
+// matching its level filter, newest at the bottom, and handles the keys which
+// scroll the list and change the filter itself. Like view keybinds, its keys may
+// be sequences and may be preceded by a count.
+type logPanel struct {
+	gotuit.BaseComponent
+	logs  *logBuffer
+	added logNotifier
+	level slog.Level
+	// scroll counts the lines scrolled up from the newest record.
+	scroll int
+	// rows is the number of records which fit below the header.
+	rows int
+	// bindings are the panel's keys, set from the keymap.
+	bindings []binding
+	pending  []gotuit.KeyChord
+	count    int
+}
+
+// logPanelActions are the actions of the "Log Panel" view, handled by the
+// panel's HandleEvent rather than bound as view keybinds.
+func (p *logPanel) actions() []Action {
+	return []Action{
+		{"scroll-up", []string{"k", "Up"}, "Up", "Scroll to older records", p.onScrollUp},
+		{"scroll-down", []string{"j", "Down"}, "Down", "Scroll to newer records", p.onScrollDown},
+		{"oldest", []string{"g g"}, "Oldest", "Jump to the oldest record", p.onOldest},
+		{"newest", []string{"G"}, "Newest", "Jump to the newest record", p.onNewest},
+		{"cycle-level", []string{"l"}, "[L]evel", "Cycle the minimum level shown", p.onCycleLevel},
+		{"exit", []string{"Esc"}, "Exit", "Close the log panel", p.onExit},
+	}
+}
+
+// HandleEvent runs the action bound to the keys typed, once they complete a
+// binding. Keys which neither start nor complete one are passed on.
+func (p *logPanel) HandleEvent(v *gotuit.View, ev tcell.Event) bool {
+	evKey, ok := ev.(*tcell.EventKey)
+	if !ok {
+		return false
+	}
+	key := gotuit.NewKeyChord(evKey)
+	if len(p.pending) == 0 && key == gotuit.RuneChord(key.Rune) && key.Rune >= '0' && key.Rune <= '9' && (key.Rune != '0' || p.count > 0) {
+		p.count = min(p.count*10+int(key.Rune-'0'), gotuit.MaxCount)
+		return true
+	}
+
+	keys := append(slices.Clone(p.pending), key)
+	for _, b := range p.bindings {
+		if slices.Equal(b.keys, keys) {
+			p.pending = nil
+			err := b.action.Callback(v)
+			p.count = 0
+			if err != nil {
+				v.App.Notify(gotuit.SeverityError, err.Error())
+			}
+			return true
+		}
+	}
+	for _, b := range p.bindings {
+		if len(b.keys) > len(keys) && slices.Equal(b.keys[:len(keys)], keys) {
+			p.pending = keys
+			return true
+		}
+	}
+	p.pending, p.count = nil, 0
+	return false
+}
+
+// keyHelp lists the panel's keys for the help modal.
+func (p *logPanel) keyHelp() []binding {
+	return p.bindings
+}
+
+// repeat returns the count typed before the current keys, or 1 without one.
+func (p *logPanel) repeat() int {
+	return max(p.count, 1)
+}
+
+func (p *logPanel) Layout(v *gotuit.View) {
+	p.rows = v.InnerHeight() - 1
+}
+
+// Focus scrolls back to the newest record whenever the panel is opened.
+func (p *logPanel) Focus(v *gotuit.View) {
+	p.scroll = 0
+}
+
+func (p *logPanel) Render(v *gotuit.View) {
+	theme := v.Theme()
+	style := theme.Style(gotuit.RolePanel)
+	entries := p.logs.filter(p.level)
+
+	header := fmt.Sprintf("Log, %s and above (%d records)", p.level, len(entries))
+	v.SetTextContent(0, 0, header, gotuit.MergeStyles(style, theme.Style(gotuit.RoleTitle)))
+
+	p.scroll = max(min(p.scroll, len(entries)-p.rows), 0)
+	end := len(entries) - p.scroll
+	start := max(end-p.rows, 0)
+	for y, entry := range entries[start:end] {
+		text := fmt.Sprintf("%s %-5s %s", entry.time.Format("15:04:05"), entry.level, entry.message)
+		if entry.attrs != "" {
+			text += " " + entry.attrs
+		}
+		lineStyle := gotuit.MergeStyles(style, theme.Style(logSeverity(entry.level).Role()))
+		v.SetTextContent(0, y+1, gotuit.Truncate(text, v.InnerWidth()), lineStyle)
+	}
+}
+
+func (m *Model) onGlobalToggleLogPanel(app *gotuit.App) error {
+	if app.TopModal() == m.logView {
+		app.PopModal()
+		return nil
+	}
+	app.PushModal(m.logView)
+	return nil
+}
+
+func (p *logPanel) onScrollUp(v *gotuit.View) error {
+	p.scroll += p.repeat()
+	return nil
+}
+
+func (p *logPanel) onScrollDown(v *gotuit.View) error {
+	p.scroll = max(p.scroll-p.repeat(), 0)
+	return nil
+}
+
+func (p *logPanel) onOldest(v *gotuit.View) error {
+	p.scroll = len(p.logs.filter(p.level))
+	return nil
+}
+
+func (p *logPanel) onNewest(v *gotuit.View) error {
+	p.scroll = 0
+	return nil
+}
+
+// onCycleLevel raises the level filter, wrapping back around to debug.
+func (p *logPanel) onCycleLevel(v *gotuit.View) error {
+	idx := slices.Index(logLevels, p.level)
+	p.level = logLevels[(idx+1)%len(logLevels)]
+	p.scroll = 0
+	return nil
+}
+
+func (p *logPanel) onExit(v *gotuit.View) error {
+	v.App.PopModal()
+	return nil
+}
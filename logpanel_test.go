@@ -0,0 +1,119 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/FFX01/gettuit/internal/gotuit"
+	"github.com/gdamore/tcell/v2"
+)
+
+// newTestLogPanel returns a log panel holding 50 info records, bound to the keys
+// of the default keymap, and the view hosting it.
+func newTestLogPanel(t *testing.T) (*logPanel, *gotuit.View) {
+	logs := &logBuffer{}
+	for i := range 50 {
+		logs.add(logEntry{time: time.Now(), level: slog.LevelInfo, message: fmt.Sprintf("record %d", i)})
+	}
+	model := &Model{logPanel: &logPanel{logs: logs, level: slog.LevelInfo}}
+	keymap, err := newKeymap(Config{}, model.actions(), model.globalActions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	model.logPanel.bindings = keymap.Bindings("Log Panel", gotuit.NormalMode)
+	return model.logPanel, gotuit.NewComponentView("Log Panel", 0, 0, 80, 12, model.logPanel)
+}
+
+// typeKeys feeds keys, parsed like gotuit.View.Bind, to the panel's HandleEvent.
+// It reports whether the panel handled every one of them.
+func typeKeys(t *testing.T, p *logPanel, v *gotuit.View, keys string) bool {
+	chords, err := gotuit.ParseKeys(keys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	handled := true
+	for _, kc := range chords {
+		handled = p.HandleEvent(v, tcell.NewEventKey(kc.Key, kc.Rune, kc.Mod)) && handled
+	}
+	return handled
+}
+
+func TestLogPanelScroll(t *testing.T) {
+	p, v := newTestLogPanel(t)
+	for _, step := range []struct {
+		keys   string
+		scroll int
+	}{
+		{"k", 1},
+		{"5 k", 6},
+		{"Up", 7},
+		{"2 j", 5},
+		{"1 0 j", 0},
+		{"g g", 50},
+		{"G", 0},
+	} {
+		if !typeKeys(t, p, v, step.keys) {
+			t.Fatalf("'%s' wasn't handled", step.keys)
+		}
+		if p.scroll != step.scroll {
+			t.Fatalf("Scrolled to %d after '%s', want %d", p.scroll, step.keys, step.scroll)
+		}
+	}
+}
+
+func TestLogPanelCycleLevel(t *testing.T) {
+	p, v := newTestLogPanel(t)
+	p.scroll = 3
+	for _, want := range []slog.Level{slog.LevelWarn, slog.LevelError, slog.LevelDebug, slog.LevelInfo} {
+		typeKeys(t, p, v, "l")
+		if p.level != want || p.scroll != 0 {
+			t.Fatalf("Got level %s scrolled to %d, want %s scrolled to 0", p.level, p.scroll, want)
+		}
+	}
+}
+
+func TestLogPanelPassesOtherKeys(t *testing.T) {
+	p, v := newTestLogPanel(t)
+	if typeKeys(t, p, v, "x") {
+		t.Fatal("The panel handled a key it doesn't bind")
+	}
+	if typeKeys(t, p, v, "g x") {
+		t.Fatal("The panel handled a key which doesn't complete a sequence")
+	}
+	if !typeKeys(t, p, v, "k") || p.scroll != 1 {
+		t.Fatal("A broken sequence left keys pending")
+	}
+}
+
+func TestBufferHandlerRedrawsPanel(t *testing.T) {
+	screen := tcell.NewSimulationScreen("")
+	app, err := gotuit.NewAppWithScreen(screen)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer app.Cleanup()
+
+	notify := &logNotifier{}
+	renders := 0
+	v := gotuit.NewView("Log Panel", 0, 0, 80, 12, func(*gotuit.View) {
+		renders++
+	})
+	v.Watch(&notify.state)
+	app.AddView(v)
+	app.Draw()
+
+	logger := slog.New(&bufferHandler{buffer: &logBuffer{}, app: app, notify: notify})
+	logger.Debug("first")
+	logger.Debug("second")
+	if err := app.Post(func(app *gotuit.App) { app.Quit() }); err != nil {
+		t.Fatal(err)
+	}
+	if err := app.MainLoop(); err != nil {
+		t.Fatal(err)
+	}
+	if renders != 2 {
+		t.Fatalf("Panel rendered %d times, want 2", renders)
+	}
+}
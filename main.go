@@ -2,33 +2,66 @@ package main
 
 import (
 	"encoding/json"
+	"flag"
 	"fmt"
 	"log"
 	"log/slog"
 	"os"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/FFX01/gettuit/internal/gotuit"
-	"github.com/gdamore/tcell/v2"
 )
 
 var modeMap = map[gotuit.Mode]string{
 	gotuit.NormalMode: "Normal",
 	gotuit.InputMode:  "Input",
+	gotuit.VisualMode: "Visual",
 }
 
-var (
-	backgroundColor  tcell.Color = tcell.NewHexColor(0x284B63)
-	foregroundColor  tcell.Color = tcell.NewHexColor(0xF4F9E9)
-	focusBorderColor tcell.Color = tcell.NewHexColor(0x5BC0BE)
+type Model struct {
+	todos         []Todo
+	helpModal     *gotuit.View
+	helpFor       *gotuit.View
+	searchText    string
+	searchMatches []searchMatch
+	filter        *Filter
+	prompt        promptKind
+	savedViews    []SavedView
+	sort          SortMode
+	selection     Selection
+	undoStack     [][]Todo
+	register      []Todo
+	themes        []*gotuit.Theme
+	listOffset    int
+	drag          todoDrag
+	wrap          bool
+	logPanel      *logPanel
+	logView       *gotuit.View
+	confirmations bool
+	quitPending   bool
+	saveErr       error
+	// state is marked changed along with the todos, filter, sort or wrapping, so
+	// every view showing them is rendered again.
+	state gotuit.State
+}
+
+// promptKind determines what the "Search Line" view does with its input.
+type promptKind int
+
+const (
+	promptSearch promptKind = iota
+	promptFilter
+	promptSaveView
+	promptTag
+	promptPriority
 )
 
-type Model struct {
-	todos             []Todo
-	helpModalViewName string
-	searchText        string
-	searchMatches     []searchMatch
+var promptPlaceholders = map[promptKind]string{
+	promptSearch: "text to find",
+	promptFilter: "e.g. is:open #work due:today",
+	promptTag:    "#tag, -#tag to remove",
 }
 
 type searchMatch struct {
@@ -58,23 +91,43 @@ func (m *Model) loadFromDisk() error {
 		nt := Todo{
 			text:     t.Text,
 			complete: t.Complete,
+			created:  t.Created,
 		}
 		newTodos = append(newTodos, nt)
 	}
 	m.todos = newTodos
 
+	if data.Views != nil {
+		m.loadSavedViews(data.Views)
+	}
+
 	return nil
 }
 
+// SaveToDisk writes the todos and saved views to disk. A failure is remembered
+// so quitting can warn about losing changes.
 func (m *Model) SaveToDisk() error {
-	data := DataSchema{}
+	m.state.Changed()
+	m.saveErr = m.writeToDisk()
+	if m.saveErr != nil {
+		return fmt.Errorf("Unable to save todos: %w", m.saveErr)
+	}
+	return nil
+}
+
+func (m *Model) writeToDisk() error {
+	data := DataSchema{Views: []SavedViewSchema{}}
 	for _, t := range m.todos {
 		todoData := TodoDataSchema{
 			Text:     t.text,
 			Complete: t.complete,
+			Created:  t.created,
 		}
 		data.Todos = append(data.Todos, todoData)
 	}
+	for _, sv := range m.savedViews {
+		data.Views = append(data.Views, SavedViewSchema{Name: sv.name, Query: sv.filter.Query})
+	}
 
 	marshalledData, err := json.Marshal(data)
 	if err != nil {
@@ -91,94 +144,225 @@ func (m *Model) SaveToDisk() error {
 
 func (m *Model) Init() {
 	m.todos = []Todo{}
+	m.loadSavedViews(defaultSavedViews)
 	m.loadFromDisk()
 }
 
+// visibleTodos returns the indexes into m.todos of every todo that should be
+// rendered in the "Todo List" view, in display order.
+func (m *Model) visibleTodos() []int {
+	visible := make([]int, 0, len(m.todos))
+	today := startOfDay(time.Now())
+	for idx, t := range m.todos {
+		if m.filter == nil || m.filter.Match(t, today) {
+			visible = append(visible, idx)
+		}
+	}
+	sortTodos(m.todos, visible, m.sort)
+	return visible
+}
+
+// todoIndex maps a row in the "Todo List" view to an index into m.todos.
+func (m *Model) todoIndex(row int) (int, bool) {
+	visible := m.visibleTodos()
+	if row < 0 || row >= len(visible) {
+		return 0, false
+	}
+	return visible[row], true
+}
+
+// todoRow maps an index into m.todos to its row in the "Todo List" view. It
+// returns -1 if the todo is hidden by the active filter.
+func (m *Model) todoRow(idx int) int {
+	return slices.Index(m.visibleTodos(), idx)
+}
+
+// followTodo moves the cursor of v to the row displaying m.todos[idx], if it is
+// still visible.
+func (m *Model) followTodo(v *gotuit.View, idx int) {
+	if row := m.todoRow(idx); row != -1 {
+		v.Cursory = row
+	}
+	m.clampCursor(v)
+}
+
+// clampCursor keeps the cursor of v within the visible todos.
+func (m *Model) clampCursor(v *gotuit.View) {
+	count := len(m.visibleTodos())
+	if v.Cursory >= count {
+		v.Cursory = count - 1
+	}
+	if v.Cursory < 0 {
+		v.Cursory = 0
+	}
+}
+
 func (m *Model) renderSearchLine(v *gotuit.View) {
 	prefix := "Search: "
+	switch m.prompt {
+	case promptFilter:
+		prefix = "Filter: "
+	case promptSaveView:
+		prefix = "Save view as: "
+	case promptTag:
+		prefix = "Tag selection: "
+	case promptPriority:
+		prefix = "Priority (1-9, empty to clear): "
+	}
 
-	text := prefix + string(v.GetInputBuffer())
-	v.SetTextContent(0, 0, text, tcell.StyleDefault.Background(backgroundColor))
+	style := v.Theme().Style(gotuit.RolePanel)
+	v.Input.Placeholder = promptPlaceholders[m.prompt]
+	v.SetTextContent(0, 0, prefix, style)
+	v.Input.Render(v, len(prefix), 0, v.InnerWidth()-len(prefix), style)
 }
 
 func (m *Model) renderTitle(v *gotuit.View) {
+	style := v.Theme().Style(gotuit.RoleTitle)
 	text := " Todo List, 'Ctrl+c' to quit, press 'F1' for help "
-	v.SetTextContent(0, 0, text, tcell.StyleDefault)
+	v.SetTextContent(0, 0, text, style)
+
+	clock := v.App.Now().Format("15:04 ")
+	v.SetTextContent(v.InnerWidth()-gotuit.StringWidth(clock), 0, clock, style)
+}
+
+// startClock redraws the "Title" view at the start of every minute so its clock
+// stays current.
+func startClock(title *gotuit.View) {
+	now := title.App.Now()
+	untilNextMinute := now.Truncate(time.Minute).Add(time.Minute).Sub(now)
+	title.App.After(untilNextMinute, func(app *gotuit.App) {
+		title.Invalidate()
+		app.Every(time.Minute, func(*gotuit.App) {
+			title.Invalidate()
+		})
+	})
 }
 
 func (m *Model) renderHelpModal(v *gotuit.View) {
 	height := v.InnerHeight()
+	style := v.Theme().Style(gotuit.RolePanel)
 
 	exitText := "`Esc` to exit help"
-	v.SetTextContent(0, height, exitText, tcell.StyleDefault.Background(backgroundColor))
+	v.SetTextContent(0, height-1, exitText, style)
 
-	viewForHelp, ok := v.App.GetView(m.helpModalViewName)
-	if !ok {
-		log.Fatal("No Focused View")
-	}
+	viewForHelp := m.helpFor
 
 	description := fmt.Sprintf("Help for %s, %s mode", viewForHelp.Name, modeMap[viewForHelp.Mode])
-	v.SetTextContent(0, 0, description, tcell.StyleDefault.Background(backgroundColor))
+	v.SetTextContent(0, 0, description, style)
+
+	// Keys the focused view doesn't handle bubble up to its ancestors, so their
+	// keybinds are listed too, except for the ones a descendant shadows.
+	shadowed := map[string]bool{}
+	y := 2
+	for view := viewForHelp; view != nil; view = view.Parent {
+		if view != viewForHelp && y < height-1 {
+			v.SetTextContent(0, y, fmt.Sprintf("From %s, %s mode", view.Name, modeMap[view.Mode]), style)
+			y++
+		}
+		for _, line := range helpLines(view) {
+			if shadowed[line.keys] {
+				continue
+			}
+			shadowed[line.keys] = true
+			if y >= height-1 {
+				return
+			}
+			v.SetTextContent(0, y, line.text, style)
+			y++
+		}
+	}
+}
 
-	yidx := 0
-	for _, kb := range viewForHelp.Keybinds {
-		if kb.Mode() != viewForHelp.Mode {
-			continue
+// helpLine is a key listed in the help modal.
+type helpLine struct {
+	keys, text string
+}
+
+// keyHelper is implemented by components which handle keys themselves, like
+// logPanel.
+type keyHelper interface {
+	keyHelp() []binding
+}
+
+// helpLines returns the keys view handles in its current mode. Keys handled by
+// its component come first, since the component sees them before the keybinds.
+func helpLines(view *gotuit.View) []helpLine {
+	lines := []helpLine{}
+	if helper, ok := view.Component().(keyHelper); ok {
+		for _, b := range helper.keyHelp() {
+			keys := gotuit.KeysString(b.keys)
+			lines = append(lines, helpLine{keys, fmt.Sprintf("%s - %s [%s]", keys, b.action.Name, b.action.Description)})
 		}
-		text := kb.String()
-		v.SetTextContent(0, yidx+2, text, tcell.StyleDefault.Background(backgroundColor))
-		yidx++
 	}
+	for _, kb := range view.Keybinds {
+		if kb.Mode() == view.Mode {
+			lines = append(lines, helpLine{gotuit.KeysString(kb.Keys()), kb.String()})
+		}
+	}
+	return lines
 }
 
 func (m *Model) renderTodos(v *gotuit.View) {
-	for idx, todo := range m.todos {
-		style := tcell.StyleDefault
+	theme := v.Theme()
+	today := startOfDay(time.Now())
+	visible := m.visibleTodos()
+	layout := m.layoutTodos(v)
+	m.scrollToCursor(v, layout)
+	matches := map[int][]searchMatch{}
+	for _, sm := range m.searchMatches {
+		matches[sm.y] = append(matches[sm.y], sm)
+	}
+
+	for y, line := range m.screenLines(v, layout) {
+		idx := visible[line.row]
+		todo := m.todos[idx]
+		style := theme.Style(gotuit.RoleNormal)
 		prefix := "[ ]"
 		if todo.complete {
 			prefix = "[x]"
+			style = theme.Style(gotuit.RoleCompleted)
 		} else if todo.temp {
 			prefix = "#>"
+		} else if due, ok := todo.due(); ok && due.Before(today) {
+			style = theme.Style(gotuit.RoleOverdue)
 		}
 
-		if idx == v.Cursory {
-			style = style.Background(tcell.ColorGray)
+		if line.row == v.Cursory {
+			style = gotuit.MergeStyles(style, theme.Style(gotuit.RoleSelection))
+		} else if m.isSelected(v, line.row, idx) {
+			style = gotuit.MergeStyles(style, theme.Style(gotuit.RoleMarked))
 		}
 
-		var text string
 		if todo.temp {
-			text = fmt.Sprintf("%s %s", prefix, string(v.GetInputBuffer()))
-		} else {
-			text = fmt.Sprintf("%s %s", prefix, todo.text)
+			v.SetTextContent(0, y, prefix+" ", style)
+			v.Input.Render(v, len(prefix)+1, y, v.InnerWidth()-len(prefix)-1, style)
+			continue
 		}
 
-		v.SetTextContent(0, idx, text, style)
-
-		if v.Mode == gotuit.InputMode && todo.temp {
-			v.Cursorx = len(prefix) + v.InputCursor + 1
-			v.ShowCursor()
+		text := todo.text[line.start:line.end]
+		if line.ellipsis {
+			text += gotuit.Ellipsis
 		}
-	}
-
-	if len(m.searchMatches) > 0 {
-		for _, sm := range m.searchMatches {
-			t := m.todos[sm.y]
-			text := t.text[sm.x : sm.x+sm.len]
-			v.SetTextContent(sm.x+4, sm.y, text, tcell.StyleDefault.Background(tcell.ColorDarkGreen))
+		if line.start == 0 {
+			text = prefix + " " + text
+		} else {
+			text = strings.Repeat(" ", todoIndent) + text
 		}
-	}
+		v.SetTextContent(0, y, text, style)
 
-	if v.IsFocused() {
-		v.SetBorderColor(focusBorderColor)
-	} else {
-		v.SetBorderColor(tcell.ColorDefault)
+		for _, sm := range matches[idx] {
+			start, end := max(sm.x, line.start), min(sm.x+sm.len, line.end)
+			if start >= end {
+				continue
+			}
+			col := todoIndent + gotuit.StringWidth(todo.text[line.start:start])
+			v.SetTextContent(col, y, todo.text[start:end], theme.Style(gotuit.RoleSearchMatch))
+		}
 	}
 }
 
 func (m *Model) renderStatusLine(v *gotuit.View) {
-	style := tcell.StyleDefault.
-		Background(backgroundColor).
-		Foreground(foregroundColor)
+	style := v.Theme().Style(gotuit.RoleStatusBar)
 
 	focusedView, err := v.App.GetFocusedView()
 	var mode string
@@ -188,247 +372,480 @@ func (m *Model) renderStatusLine(v *gotuit.View) {
 	} else {
 		mode = modeMap[focusedView.Mode]
 	}
+	var pending string
+	if focusedView != nil {
+		if view, err := focusedView.GetFocusedView(); err == nil {
+			pending = view.PendingKeys()
+		}
+	}
 
 	statusText := " Mode: " + mode
-
-	logLine := v.App.PopLog()
-	if logLine != "" {
-		statusText += ", Log: " + logLine
+	if m.sort != SortManual {
+		statusText += ", Sort: " + m.sort.String()
+	}
+	if m.wrap {
+		statusText += ", Wrap"
+	}
+	if pending != "" {
+		statusText += ", Keys: " + pending
+	}
+	if m.filter != nil && m.filter.Name != "" {
+		statusText += fmt.Sprintf(", View: %s (%s)", m.filter.Name, m.filter.Query)
+	} else if m.filter != nil {
+		statusText += ", Filter: " + m.filter.Query
 	}
+
 	v.SetTextContent(0, 0, statusText, style)
+
+	msg, ok := v.App.Status()
+	if !ok {
+		return
+	}
+	col := gotuit.StringWidth(statusText) + 1
+	text := fmt.Sprintf("[%s] %s", msg.Severity, msg)
+	msgStyle := gotuit.MergeStyles(style, v.Theme().Style(msg.Severity.Role()))
+	v.SetTextContent(col, 0, gotuit.Truncate(text, v.InnerWidth()-col), msgStyle)
 }
 
 func (m *Model) renderTestChild(v *gotuit.View) {
-	style := tcell.StyleDefault.Background(backgroundColor).Foreground(foregroundColor)
+	style := v.Theme().Style(gotuit.RolePanel)
 	text := "Child Test, Focused: "
 	if v.IsFocused() {
 		text += "true"
-		v.SetBorderColor(focusBorderColor)
 	} else {
 		text += "false"
-		v.SetBorderColor(tcell.ColorDefault)
 	}
 	v.SetTextContent(0, 0, text, style)
 }
 
 type DataSchema struct {
-	Todos []TodoDataSchema `json:"todos"`
+	Todos []TodoDataSchema  `json:"todos"`
+	Views []SavedViewSchema `json:"views"`
 }
 
-func (m *Model) onTodoListToggleComplete(v *gotuit.View) {
-	m.todos[v.Cursory].complete = !m.todos[v.Cursory].complete
-	err := m.SaveToDisk()
-	if err != nil {
-		log.Fatal(err)
+func (m *Model) onTodoListToggleComplete(v *gotuit.View) error {
+	idx, ok := m.todoIndex(v.Cursory)
+	if !ok {
+		return nil
 	}
-	log.Println("Toggle Todo")
+	m.checkpoint()
+	m.todos[idx].complete = !m.todos[idx].complete
+	m.clampCursor(v)
+	return m.SaveToDisk()
 }
 
-func (m *Model) onTodoListJumpToTop(v *gotuit.View) {
-	v.Cursory = 0
+// onTodoListJumpToTop jumps to the first todo, or to the todo numbered by the
+// count prefix.
+func (m *Model) onTodoListJumpToTop(v *gotuit.View) error {
+	v.Cursory = v.Count() - 1
+	m.clampCursor(v)
+	return nil
 }
 
-func (m *Model) onTodoListJumpToBottom(v *gotuit.View) {
-	v.Cursory = len(m.todos) - 1
+// onTodoListJumpToBottom jumps to the last todo, or to the todo numbered by the
+// count prefix.
+func (m *Model) onTodoListJumpToBottom(v *gotuit.View) error {
+	if v.HasCount() {
+		v.Cursory = v.Count() - 1
+	} else {
+		v.Cursory = len(m.visibleTodos()) - 1
+	}
+	m.clampCursor(v)
+	return nil
 }
 
-func (m *Model) onTodoListAddTodo(v *gotuit.View) {
+func (m *Model) onTodoListAddTodo(v *gotuit.View) error {
 	log.Println("Adding todo...")
 	v.Mode = gotuit.InputMode
-	t := Todo{temp: true}
+	t := Todo{temp: true, created: time.Now()}
 
-	if len(m.todos) > 0 {
-		m.todos = slices.Insert(m.todos, v.Cursory+1, t)
-		v.Cursory++
-	} else {
-		m.todos = []Todo{t}
+	pos := len(m.todos)
+	if idx, ok := m.todoIndex(v.Cursory); ok {
+		pos = idx + 1
 	}
+	m.todos = slices.Insert(m.todos, pos, t)
+	m.state.Changed()
+	v.Cursory = m.todoRow(pos)
+	return nil
 }
 
-func (m *Model) onTodoListInputEscape(v *gotuit.View) {
-	todo := m.todos[v.Cursory]
-	if todo.temp && todo.text == "" {
-		m.todos = slices.Delete(m.todos, v.Cursory, v.Cursory+1)
-	} else {
-		todo.temp = false
-		m.todos[v.Cursory] = todo
+func (m *Model) onTodoListInputEscape(v *gotuit.View) error {
+	idx, ok := m.todoIndex(v.Cursory)
+	if ok {
+		todo := m.todos[idx]
+		if todo.temp && todo.text == "" {
+			m.todos = slices.Delete(m.todos, idx, idx+1)
+			m.state.Changed()
+		} else {
+			todo.temp = false
+			m.todos[idx] = todo
+		}
 	}
+	m.clampCursor(v)
 
 	v.Mode = gotuit.NormalMode
-	v.ClearInputBuffer()
+	v.Input.Clear()
 	v.HideCursor()
+	return nil
 }
 
-func (m *Model) onTodoListEditTodo(v *gotuit.View) {
+func (m *Model) onTodoListEditTodo(v *gotuit.View) error {
+	idx, ok := m.todoIndex(v.Cursory)
+	if !ok {
+		return nil
+	}
 	v.Mode = gotuit.InputMode
-	m.todos[v.Cursory].temp = true
-	textAsRunes := []rune(m.todos[v.Cursory].text)
-	v.SetInputBuffer(textAsRunes)
-	v.InputCursor = len(textAsRunes)
+	m.todos[idx].temp = true
+	v.Input.SetText(m.todos[idx].text)
+	return nil
 }
 
-func (m *Model) onTodoListReplaceTodo(v *gotuit.View) {
+func (m *Model) onTodoListReplaceTodo(v *gotuit.View) error {
+	idx, ok := m.todoIndex(v.Cursory)
+	if !ok {
+		return nil
+	}
 	v.Mode = gotuit.InputMode
-	m.todos[v.Cursory].temp = true
-	v.ClearInputBuffer()
+	m.todos[idx].temp = true
+	v.Input.Clear()
+	return nil
 }
 
-func (m *Model) onTodoListDeleteTodo(v *gotuit.View) {
-	newTodos := make([]Todo, 0)
-	newTodos = append(newTodos, m.todos[:v.Cursory]...)
-	newTodos = append(newTodos, m.todos[v.Cursory+1:]...)
-	m.todos = newTodos
+// cursorTodos returns the indexes into m.todos of the todo on the cursor and the
+// todos below it, as many as the count prefix asks for.
+func (m *Model) cursorTodos(v *gotuit.View) []int {
+	visible := m.visibleTodos()
+	if v.Cursory < 0 || v.Cursory >= len(visible) {
+		return nil
+	}
+	end := v.Cursory + min(v.Count(), len(visible)-v.Cursory)
+	return slices.Clone(visible[v.Cursory:end])
+}
+
+// deleteTodos removes the todos at indexes from m.todos.
+func (m *Model) deleteTodos(indexes []int) {
+	remaining := []Todo{}
+	for idx, t := range m.todos {
+		if !slices.Contains(indexes, idx) {
+			remaining = append(remaining, t)
+		}
+	}
+	m.todos = remaining
+}
+
+func (m *Model) onTodoListDeleteTodo(v *gotuit.View) error {
+	indexes := m.cursorTodos(v)
+	if len(indexes) < 1 {
+		return nil
+	}
+
+	message := fmt.Sprintf("Delete '%s'?", m.todos[indexes[0]].text)
+	if len(indexes) > 1 {
+		message = fmt.Sprintf("Delete %d todos?", len(indexes))
+	}
+	return m.confirm(v.App, "Delete", message, func() error {
+		return m.deleteCursorTodos(v, indexes)
+	})
+}
+
+func (m *Model) deleteCursorTodos(v *gotuit.View, indexes []int) error {
+	m.checkpoint()
+	m.deleteTodos(indexes)
 
 	if v.Cursory > 0 {
 		v.Cursory--
 	} else {
 		v.Cursory = 0
 	}
-	m.SaveToDisk()
+	m.clampCursor(v)
+	return m.SaveToDisk()
+}
+
+func (m *Model) onTodoListYankTodo(v *gotuit.View) error {
+	m.register = []Todo{}
+	for _, idx := range m.cursorTodos(v) {
+		m.register = append(m.register, m.todos[idx])
+	}
+	log.Printf("Yanked %d todos", len(m.register))
+	return nil
 }
 
-func (m *Model) onTodoListConfirmTodo(v *gotuit.View) {
-	m.todos[v.Cursory].text = string(v.GetInputBuffer())
-	m.todos[v.Cursory].temp = false
+// onTodoListPasteTodo inserts the yanked todos below the cursor, repeated as many
+// times as the count prefix asks for.
+func (m *Model) onTodoListPasteTodo(v *gotuit.View) error {
+	if len(m.register) < 1 {
+		return nil
+	}
+
+	pos := len(m.todos)
+	if idx, ok := m.todoIndex(v.Cursory); ok {
+		pos = idx + 1
+	}
+
+	pasted := []Todo{}
+	for range v.Count() {
+		for _, t := range m.register {
+			t.created = time.Now()
+			pasted = append(pasted, t)
+		}
+	}
+
+	m.checkpoint()
+	m.todos = slices.Insert(m.todos, pos, pasted...)
+	m.followTodo(v, pos)
+	return m.SaveToDisk()
+}
+
+func (m *Model) onTodoListConfirmTodo(v *gotuit.View) error {
+	idx, ok := m.todoIndex(v.Cursory)
+	if !ok {
+		return nil
+	}
+	m.checkpoint()
+	m.todos[idx].text = v.Input.Text()
+	m.todos[idx].temp = false
+	m.followTodo(v, idx)
 	v.Mode = gotuit.NormalMode
 	v.Cursorx = 0
 	v.HideCursor()
-	v.ClearInputBuffer()
-	m.SaveToDisk()
+	v.Input.Clear()
+	return m.SaveToDisk()
 }
 
-func (m *Model) onTodoListMoveTodoDown(v *gotuit.View) {
-	if v.Cursory < len(m.todos)-1 {
-		m.todos[v.Cursory], m.todos[v.Cursory+1] = m.todos[v.Cursory+1], m.todos[v.Cursory]
+// swapRows swaps the todos displayed at rows a and b of the "Todo List" view.
+func (m *Model) swapRows(a, b int) {
+	visible := m.visibleTodos()
+	ia, ib := visible[a], visible[b]
+	m.todos[ia], m.todos[ib] = m.todos[ib], m.todos[ia]
+}
+
+// canReorder reports whether todos can be moved by hand. Moving todos while a sort
+// is active would change the manual order in ways the user can't see.
+func (m *Model) canReorder() bool {
+	if m.sort != SortManual {
+		log.Println("Switch to manual sort to reorder todos")
+		return false
+	}
+	return true
+}
+
+func (m *Model) onTodoListMoveTodoDown(v *gotuit.View) error {
+	if !m.canReorder() {
+		return nil
+	}
+	if v.Cursory < len(m.visibleTodos())-1 {
+		m.checkpoint()
+		m.swapRows(v.Cursory, v.Cursory+1)
 		v.Cursory++
-		m.SaveToDisk()
+		return m.SaveToDisk()
 	}
+	return nil
 }
 
-func (m *Model) onTodoListMoveTodoUp(v *gotuit.View) {
+func (m *Model) onTodoListMoveTodoUp(v *gotuit.View) error {
+	if !m.canReorder() {
+		return nil
+	}
 	if v.Cursory > 0 {
-		m.todos[v.Cursory], m.todos[v.Cursory-1] = m.todos[v.Cursory-1], m.todos[v.Cursory]
+		m.checkpoint()
+		m.swapRows(v.Cursory, v.Cursory-1)
 		v.Cursory--
-		m.SaveToDisk()
+		return m.SaveToDisk()
 	}
+	return nil
 }
 
-func (m *Model) onTodoListInputBackspace(v *gotuit.View) {
-	text := v.GetInputBuffer()
-	log.Println("Cursor x: ", v.InputCursor)
+type Todo struct {
+	text     string
+	complete bool
+	temp     bool
+	created  time.Time
+}
 
-	if v.InputCursor > 0 {
-		head := text[:v.InputCursor-1]
-		var tail []rune
-		if v.InputCursor < len(text) {
-			tail = text[v.InputCursor:]
+// tags returns the lowercased '#tag' words found in the todo text.
+func (t Todo) tags() []string {
+	tags := []string{}
+	for _, word := range strings.Fields(t.text) {
+		if len(word) > 1 && strings.HasPrefix(word, "#") {
+			tags = append(tags, strings.ToLower(word[1:]))
 		}
-		text = append(head, tail...)
-		v.SetInputBuffer(text)
-		v.InputCursor--
 	}
+	return tags
 }
 
-func (m *Model) onTodoListInputLeft(v *gotuit.View) {
-	if v.InputCursor > 0 {
-		v.InputCursor--
-	}
+func (t Todo) hasTag(tag string) bool {
+	return slices.Contains(t.tags(), tag)
 }
 
-func (m *Model) onTodoListInputRight(v *gotuit.View) {
-	lineLen := len(v.GetInputBuffer())
-	if v.InputCursor < lineLen {
-		v.InputCursor++
+// due returns the date given by a 'due:YYYY-MM-DD' word in the todo text.
+func (t Todo) due() (time.Time, bool) {
+	for _, word := range strings.Fields(t.text) {
+		value, found := strings.CutPrefix(word, "due:")
+		if !found {
+			continue
+		}
+		d, err := time.ParseInLocation(dateLayout, value, time.Local)
+		if err == nil {
+			return d, true
+		}
 	}
+	return time.Time{}, false
 }
 
-type Todo struct {
-	text     string
-	complete bool
-	temp     bool
+// priority returns N for a '!N' word in the todo text, where N is a digit from 1
+// to 9 and lower is more urgent.
+func (t Todo) priority() (int, bool) {
+	for _, word := range strings.Fields(t.text) {
+		if len(word) == 2 && word[0] == '!' && word[1] >= '1' && word[1] <= '9' {
+			return int(word[1] - '0'), true
+		}
+	}
+	return 0, false
 }
 
 type TodoDataSchema struct {
-	Text     string `json:"text"`
-	Complete bool   `json:"complete"`
-	Temp     bool   `json:"temp"`
+	Text     string    `json:"text"`
+	Complete bool      `json:"complete"`
+	Temp     bool      `json:"temp"`
+	Created  time.Time `json:"created"`
 }
 
-func (m *Model) onTodoListCursorDown(v *gotuit.View) {
-	if v.Cursory < len(m.todos)-1 {
-		v.Cursory++
+func (m *Model) onTodoListCursorDown(v *gotuit.View) error {
+	v.Cursory = min(v.Cursory+v.Count(), len(m.visibleTodos())-1)
+	m.clampCursor(v)
+	return nil
+}
+
+func (m *Model) onTodoListCursorUp(v *gotuit.View) error {
+	v.Cursory = max(v.Cursory-v.Count(), 0)
+	return nil
+}
+
+// confirm runs action once the user confirms it in a dialog, or right away if
+// confirmations are turned off in the config.
+func (m *Model) confirm(app *gotuit.App, title, message string, action func() error) error {
+	if !m.confirmations {
+		return action()
 	}
+	app.Confirm(title, message, func(yes bool) error {
+		if !yes {
+			return nil
+		}
+		return action()
+	})
+	return nil
 }
 
-func (m *Model) onTodoListCursorUp(v *gotuit.View) {
-	if v.Cursory > 0 {
-		v.Cursory--
+// getView returns the view called name. main creates every view the handlers
+// look up, so an error here is a bug.
+func getView(app *gotuit.App, name string) (*gotuit.View, error) {
+	v, ok := app.GetView(name)
+	if !ok {
+		return nil, fmt.Errorf("View '%s' does not exist", name)
 	}
+	return v, nil
 }
 
-func onGlobalQuit(app *gotuit.App) {
-	app.Quit()
+// onGlobalQuit saves and quits. If a todo is being edited or saving fails the
+// user is asked first, pressing quit again while asked quits anyway.
+func (m *Model) onGlobalQuit(app *gotuit.App) error {
+	if m.quitPending {
+		app.Quit()
+		return nil
+	}
+
+	message := ""
+	list, ok := app.GetView("Todo List")
+	if ok && list.Mode == gotuit.InputMode {
+		message = "Discard the todo being edited and quit?"
+	} else if err := m.SaveToDisk(); err != nil {
+		message = "Saving failed, quit and lose changes?"
+	}
+	if message == "" || !m.confirmations {
+		app.Quit()
+		return nil
+	}
+
+	m.quitPending = true
+	app.Confirm("Quit", message, func(yes bool) error {
+		m.quitPending = false
+		if yes {
+			app.Quit()
+		}
+		return nil
+	})
+	return nil
 }
 
-func (m *Model) onGlobalShowHelp(app *gotuit.App) {
+func (m *Model) onGlobalShowHelp(app *gotuit.App) error {
 	focusedView, err := app.GetFocusedView()
 	if err != nil {
-		m.helpModalViewName = "Todo List"
-	} else {
-		m.helpModalViewName = focusedView.Name
+		focusedView, _ = app.GetView("Todo List")
 	}
-	app.ShowView("Help Modal")
-	err = app.Focus("Help Modal")
-	if err != nil {
-		log.Fatal("Help Modal view does not exist")
-		os.Exit(1)
+	if focusedView == m.helpModal {
+		return nil
 	}
+	m.helpFor = focusedView
+	app.PushModal(m.helpModal)
+	return nil
 }
 
-func (m *Model) onHelpExit(v *gotuit.View) {
-	v.App.HideView("Help Modal")
-	previousView, ok := v.App.GetView(m.helpModalViewName)
-	if !ok {
-		log.Fatal("Something went terribly wrong")
-	}
-	err := v.App.Focus(previousView.Name)
+func (m *Model) onHelpExit(v *gotuit.View) error {
+	v.App.PopModal()
+	return nil
+}
+
+func (m *Model) onEnterSearchMode(v *gotuit.View) error {
+	m.prompt = promptSearch
+	_, err := m.openPrompt(v)
+	return err
+}
+
+func (m *Model) onEnterFilterMode(v *gotuit.View) error {
+	m.prompt = promptFilter
+	searchLine, err := m.openPrompt(v)
 	if err != nil {
-		msg := fmt.Sprintf("%s view does not exist", previousView.Name)
-		log.Fatal(msg)
-		os.Exit(1)
+		return err
 	}
+	if m.filter != nil {
+		searchLine.Input.SetText(m.filter.Query)
+	}
+	return nil
 }
 
-func onEnterSearchMode(v *gotuit.View) {
-	searchLine, ok := v.App.GetView("Search Line")
-	if !ok {
-		log.Fatal("View should exist, but doesn't somehow")
-		os.Exit(1)
+func (m *Model) onTodoListClearFilter(v *gotuit.View) error {
+	m.filter = nil
+	m.state.Changed()
+	m.clampCursor(v)
+	return nil
+}
+
+func (m *Model) openPrompt(v *gotuit.View) (*gotuit.View, error) {
+	searchLine, err := getView(v.App, "Search Line")
+	if err != nil {
+		return nil, err
 	}
 	v.App.HideView("Status Line")
 	v.App.ShowView("Search Line")
-	err := v.App.Focus("Search Line")
+	err = v.App.Focus("Search Line")
 	if err != nil {
-		log.Fatal("Search Line view does not exist")
-		os.Exit(1)
+		return nil, err
 	}
-	searchLine.SetBorderColor(focusBorderColor)
 	searchLine.Mode = gotuit.InputMode
+	return searchLine, nil
 }
 
-func onExitSearchMode(v *gotuit.View) {
-	v.ClearInputBuffer()
+func onExitSearchMode(v *gotuit.View) error {
+	v.Input.Clear()
+	v.HideCursor()
 	v.App.HideView("Search Line")
 	v.App.ShowView("Status Line")
-	err := v.App.Focus("Todo List")
-	if err != nil {
-		slog.Error("Todo List view Does not exist", "error", err)
-		os.Exit(1)
-	}
+	return v.App.Focus("Todo List")
 }
 
 func (m *Model) findSearchMatches(searchText string) {
-	for yidx, t := range m.todos {
+	for _, yidx := range m.visibleTodos() {
+		t := m.todos[yidx]
 		xidx := strings.Index(t.text, searchText)
 		if xidx != -1 {
 			sm := searchMatch{
@@ -441,187 +858,254 @@ func (m *Model) findSearchMatches(searchText string) {
 	}
 }
 
-func (m *Model) onSearchConfirm(v *gotuit.View) {
-	m.clearsearchMatches()
-	m.findSearchMatches(string(v.GetInputBuffer()))
-	v.ClearInputBuffer()
+func (m *Model) onSearchConfirm(v *gotuit.View) error {
+	list, err := getView(v.App, "Todo List")
+	if err != nil {
+		return err
+	}
+
+	switch m.prompt {
+	case promptFilter:
+		err = m.applyFilter(v.Input.Text(), list)
+	case promptSaveView:
+		err = m.saveView(strings.TrimSpace(v.Input.Text()))
+	case promptTag:
+		err = m.tagSelection(list, v.Input.Text())
+	case promptPriority:
+		err = m.prioritizeSelection(list, v.Input.Text())
+	default:
+		m.clearsearchMatches()
+		m.findSearchMatches(v.Input.Text())
+		m.state.Changed()
+	}
+
+	v.Input.Clear()
+	v.HideCursor()
 	v.Hide()
 	v.App.ShowView("Status Line")
-	err := v.App.Focus("Todo List")
-	if err != nil {
-		log.Fatal("Todo List view does not exist")
-		os.Exit(1)
+	if focusErr := v.App.Focus("Todo List"); focusErr != nil {
+		return focusErr
 	}
-	if len(m.searchMatches) > 0 {
-		list, ok := v.App.GetView("Todo List")
-		if !ok {
-			log.Fatal("This shouldn't be possible")
-		}
-		list.Cursory = m.searchMatches[0].y
+	if m.prompt == promptSearch && len(m.searchMatches) > 0 {
+		list.Cursory = m.todoRow(m.searchMatches[0].y)
 	}
+	return err
 }
 
-func (m *Model) onNextSearchMatch(v *gotuit.View) {
-	if len(m.searchMatches) < 1 {
-		return
+// applyFilter replaces the active filter with one parsed from query. An empty
+// query clears the filter.
+func (m *Model) applyFilter(query string, list *gotuit.View) error {
+	m.state.Changed()
+	if strings.TrimSpace(query) == "" {
+		m.filter = nil
+		m.clampCursor(list)
+		return nil
 	}
 
-	if v.Cursory == m.searchMatches[len(m.searchMatches)-1].y {
-		v.Cursory = m.searchMatches[0].y
-		return
+	filter, err := parseFilter(query)
+	if err != nil {
+		return err
+	}
+	m.filter = filter
+	list.Cursory = 0
+	m.clampCursor(list)
+	return nil
+}
+
+// searchMatchRows returns the rows of the "Todo List" view containing a search
+// match, in ascending order.
+func (m *Model) searchMatchRows() []int {
+	visible := m.visibleTodos()
+	rowOf := make(map[int]int, len(visible))
+	for row, idx := range visible {
+		rowOf[idx] = row
 	}
 
+	rows := []int{}
 	for _, sm := range m.searchMatches {
-		if v.Cursory < sm.y {
-			v.Cursory = sm.y
-			return
+		if row, ok := rowOf[sm.y]; ok {
+			rows = append(rows, row)
 		}
 	}
+	slices.Sort(rows)
+	return slices.Compact(rows)
 }
 
-func (m *Model) onPreviousSearchMatch(v *gotuit.View) {
-	if len(m.searchMatches) < 1 {
-		return
+func (m *Model) onNextSearchMatch(v *gotuit.View) error {
+	rows := m.searchMatchRows()
+	if len(rows) < 1 {
+		return nil
 	}
 
-	if v.Cursory == m.searchMatches[0].y {
-		v.Cursory = m.searchMatches[len(m.searchMatches)-1].y
-		return
+	if v.Cursory == rows[len(rows)-1] {
+		v.Cursory = rows[0]
+		return nil
 	}
 
-	for i := len(m.searchMatches) - 1; i >= 0; i-- {
-		sm := m.searchMatches[i]
-		if v.Cursory > sm.y {
-			v.Cursory = sm.y
-			return
+	for _, row := range rows {
+		if v.Cursory < row {
+			v.Cursory = row
+			return nil
 		}
 	}
+	return nil
 }
 
-func (m *Model) onTodoListToggleFocus(v *gotuit.View) {
-	if v.Name == "Todo List" {
-		err := v.Focus("Test Child")
-		if err != nil {
-			log.Fatal("Test Child View does not exist")
-			os.Exit(1)
-		}
-	} else if v.Name == "Test Child" {
-		err := v.Parent.Focus("Todo List")
-		if err != nil {
-			log.Fatal("Todo List view does not exist")
-			os.Exit(1)
-		}
+func (m *Model) onPreviousSearchMatch(v *gotuit.View) error {
+	rows := m.searchMatchRows()
+	if len(rows) < 1 {
+		return nil
 	}
-}
 
-func (m *Model) onTodoListEscape(v *gotuit.View) {
-	m.searchMatches = make([]searchMatch, 0)
-}
-
-type ViewObject interface {
-	Render(*gotuit.View)
-	HandleEvent(tcell.Event)
-}
-
-type Input struct {
-	buffer  []rune
-	cursorX int
-}
+	if v.Cursory == rows[0] {
+		v.Cursory = rows[len(rows)-1]
+		return nil
+	}
 
-func (self *Input) HandleEvent(ev tcell.Event) {
-	switch ev := ev.(type) {
-	case *tcell.EventKey:
-		switch ev.Key() {
-		case tcell.KeyRune:
-			self.buffer = append(self.buffer, ev.Rune())
-			return
+	for i := len(rows) - 1; i >= 0; i-- {
+		if v.Cursory > rows[i] {
+			v.Cursory = rows[i]
+			return nil
 		}
 	}
+	return nil
 }
 
-func (self *Input) Render(v *gotuit.View) {
-	v.SetTextContent(0, 0, string(self.buffer), tcell.StyleDefault)
-	self.cursorX = len(self.buffer)
-    v.ShowCursor()
+// onToggleFocus backs the "toggle-focus" action, which predates Tab moving focus
+// on its own. It is deprecated and has no default keys, it is only kept so
+// keymaps naming it still load.
+func (m *Model) onToggleFocus(v *gotuit.View) error {
+	return v.App.FocusNext()
+}
+
+func (m *Model) onTodoListEscape(v *gotuit.View) error {
+	m.searchMatches = make([]searchMatch, 0)
+	return nil
 }
 
 func main() {
-	model := Model{}
+	logFile := flag.String("log-file", "", "also write logs to `file` as JSON, for bug reports")
+	flag.Parse()
+
+	logs := &logBuffer{}
+	model := Model{logPanel: &logPanel{logs: logs, level: slog.LevelInfo}}
 	model.Init()
-	app := gotuit.NewApp()
+
+	config, err := loadConfig()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Unable to load config:", err)
+		os.Exit(1)
+	}
+	keymap, err := newKeymap(config, model.actions(), model.globalActions())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid keymap:\n%s\n", err)
+		os.Exit(1)
+	}
+	model.confirmations = !config.DisableConfirmations
+	theme, err := model.loadThemes(config)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Invalid theme:", err)
+		os.Exit(1)
+	}
+
+	app, err := gotuit.NewApp()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Unable to start:", err)
+		os.Exit(1)
+	}
 	defer app.Cleanup()
+	app.SetTheme(theme)
 
-	log.SetOutput(app)
-	slogHandler := slog.NewTextHandler(app, &slog.HandlerOptions{
-		Level: slog.LevelDebug,
-	})
-	slog.SetDefault(slog.New(slogHandler))
+	// Setting the default slog logger sends the log package's output to it too.
+	handlers := teeHandler{
+		gotuit.NewStatusHandler(app, slog.LevelInfo),
+		&bufferHandler{buffer: logs, app: app, notify: &model.logPanel.added},
+	}
+	if *logFile != "" {
+		file, err := os.OpenFile(*logFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			app.Cleanup()
+			fmt.Fprintln(os.Stderr, "Unable to open log file:", err)
+			os.Exit(1)
+		}
+		defer file.Close()
+		handlers = append(handlers, slog.NewJSONHandler(file, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	}
+	slog.SetDefault(slog.New(handlers))
 
 	width, height := app.Size()
 
-	list := gotuit.NewView("Todo List", 0, 1, width, height-4, model.renderTodos)
+	sidebarWidth := 24
+
+	savedViews := gotuit.NewView("Saved Views", 0, 1, sidebarWidth, height-4, model.renderSavedViews)
+	savedViews.SetMouseHandler(model.onSavedViewsMouse)
+	savedViews.Watch(&model.state)
+	keymap.Apply(savedViews)
+
+	list := gotuit.NewView("Todo List", sidebarWidth, 1, width-sidebarWidth, height-4, model.renderTodos)
 	list.SetPadding(1, 1, 2, 1)
-	list.Bind(gotuit.NormalMode, 'k', "Up", "Move cursor up", model.onTodoListCursorUp)
-	list.Bind(gotuit.NormalMode, 'j', "Down", "Move cursor down", model.onTodoListCursorDown)
-	list.Bind(gotuit.NormalMode, tcell.KeyUp, "Up", "Move cursor up", model.onTodoListCursorUp)
-	list.Bind(gotuit.NormalMode, tcell.KeyDown, "Down", "Move cursor down", model.onTodoListCursorDown)
-	list.Bind(gotuit.NormalMode, tcell.KeyCtrlK, "Move Up", "Move item up", model.onTodoListMoveTodoUp)
-	list.Bind(gotuit.NormalMode, tcell.KeyCtrlJ, "Move Down", "Move item down", model.onTodoListMoveTodoDown)
-	list.Bind(gotuit.NormalMode, ' ', "Toggle Complete", "Toggle completion status", model.onTodoListToggleComplete)
-	list.Bind(gotuit.NormalMode, 'a', "[A]dd Todo", "Add a new todo", model.onTodoListAddTodo)
-	list.Bind(gotuit.NormalMode, 'D', "[D]elete Todo", "Delete todo on cursor", model.onTodoListDeleteTodo)
-	list.Bind(gotuit.NormalMode, 'e', "[E]dit Todo", "Edit todo on cursor", model.onTodoListEditTodo)
-	list.Bind(gotuit.NormalMode, 'r', "[R]eplace Todo", "Replace todo with a new one", model.onTodoListReplaceTodo)
-	list.Bind(gotuit.NormalMode, tcell.KeyCtrlU, "Jump to top", "Jump to to top of list", model.onTodoListJumpToTop)
-	list.Bind(gotuit.NormalMode, tcell.KeyCtrlD, "Jump to bottom", "Jump to to bottom of list", model.onTodoListJumpToBottom)
-	list.Bind(gotuit.NormalMode, '/', "Search", "Enter search mode", onEnterSearchMode)
-	list.Bind(gotuit.NormalMode, 'n', "Next", "Next Search Match", model.onNextSearchMatch)
-	list.Bind(gotuit.NormalMode, 'N', "Previous", "Previous search match", model.onPreviousSearchMatch)
-	list.Bind(gotuit.NormalMode, tcell.KeyTAB, "Focus Toggle", "Toggle child focus", model.onTodoListToggleFocus)
-	list.Bind(gotuit.NormalMode, tcell.KeyEscape, "Exit Search", "Exit search and clear results", model.onTodoListEscape)
-	list.Bind(gotuit.InputMode, tcell.KeyEnter, "Confirm", "Confirm changes", model.onTodoListConfirmTodo)
-	list.Bind(gotuit.InputMode, tcell.KeyBackspace, "Backspace", "Backspace", model.onTodoListInputBackspace)
-	list.Bind(gotuit.InputMode, tcell.KeyBackspace2, "Backspace", "Backspace", model.onTodoListInputBackspace)
-	list.Bind(gotuit.InputMode, tcell.KeyLeft, "Left", "Move cursor left", model.onTodoListInputLeft)
-	list.Bind(gotuit.InputMode, tcell.KeyRight, "Right", "Move cursor right", model.onTodoListInputRight)
-	list.Bind(gotuit.InputMode, tcell.KeyEscape, "Exit", "Cancel Changes", model.onTodoListInputEscape)
+	list.SetMouseHandler(model.onTodoListMouse)
+	list.Input.Placeholder = "What needs doing?"
+	list.Watch(&model.state)
+	keymap.Apply(list)
 
 	testChild := gotuit.NewView("Test Child", 0, list.InnerHeight()-3, list.InnerWidth(), 3, model.renderTestChild)
-	testChild.SetFillColor(backgroundColor)
+	testChild.SetFillRole(gotuit.RolePanel)
 	list.AddChild(testChild)
-	testChild.Bind(gotuit.NormalMode, tcell.KeyTAB, "Focus Toggle", "Toggle Focus", model.onTodoListToggleFocus)
+	keymap.Apply(testChild)
 
 	title := gotuit.NewView("Title", 0, 0, width, 1, model.renderTitle)
+	title.SetFocusable(false)
 
 	statusLine := gotuit.NewView("Status Line", 0, height-3, width, 3, model.renderStatusLine)
-	statusLine.SetFillColor(backgroundColor)
+	statusLine.SetFillRole(gotuit.RoleStatusBar)
+	statusLine.SetFocusable(false)
+	statusLine.Watch(&model.state)
+	statusLine.Watch(app.InputState())
+	statusLine.Watch(app.StatusState())
 
 	helpModal := gotuit.NewView("Help Modal", width/4, height/4, width/2, height/2, model.renderHelpModal)
 	helpModal.SetPadding(0, 1, 0, 1)
-	helpModal.SetFillColor(backgroundColor)
-	helpModal.Hide()
-	helpModal.Bind(gotuit.NormalMode, tcell.KeyEscape, "Exit", "Exit Help", model.onHelpExit)
+	helpModal.SetFillRole(gotuit.RolePanel)
+	keymap.Apply(helpModal)
+	model.helpModal = helpModal
+
+	logView := gotuit.NewComponentView("Log Panel", 0, height/2, width, height/2, model.logPanel)
+	logView.SetPadding(0, 1, 0, 1)
+	logView.SetFillRole(gotuit.RolePanel)
+	logView.Watch(&model.logPanel.added.state)
+	model.logPanel.bindings = keymap.Bindings("Log Panel", gotuit.NormalMode)
+	model.logView = logView
 
 	searchLine := gotuit.NewView("Search Line", 0, height-3, width, 3, model.renderSearchLine)
-	searchLine.SetFillColor(backgroundColor)
+	searchLine.SetFillRole(gotuit.RolePanel)
 	searchLine.Hide()
-	searchLine.Bind(gotuit.InputMode, tcell.KeyEscape, "Exit", "Exit search mode", onExitSearchMode)
-	searchLine.Bind(gotuit.InputMode, tcell.KeyEnter, "Confirm", "Confirm search", model.onSearchConfirm)
+	keymap.Apply(searchLine)
 
 	app.AddView(title)
+	app.AddView(savedViews)
 	app.AddView(list)
 	app.AddView(statusLine)
-	app.AddView(helpModal)
 	app.AddView(searchLine)
 
-	err := app.Focus("Todo List")
+	err = app.Focus("Todo List")
 	if err != nil {
-		log.Fatal("Todo List View does not exist!", "error", err)
+		app.Cleanup()
+		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
 
-	app.Bind(tcell.KeyCtrlC, "Quit", "Quit program", onGlobalQuit)
-	app.Bind(tcell.KeyF1, "Help", "Show Help", model.onGlobalShowHelp)
+	keymap.ApplyGlobal(app)
+	startClock(title)
 
-	app.MainLoop()
+	// A panic leaves the todos as they were when it happened, so try to keep them.
+	err = app.MainLoop()
+	if err != nil {
+		app.Cleanup()
+		fmt.Fprintln(os.Stderr, err)
+		if err := model.SaveToDisk(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+		os.Exit(1)
+	}
 }
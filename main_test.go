@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/FFX01/gettuit/internal/gotuit"
+	"github.com/gdamore/tcell/v2"
+)
+
+// newBenchmarkList returns a model holding n todos, rendered by a "Todo List"
+// view on a 300 by 100 simulation screen.
+func newBenchmarkList(b *testing.B, n int) (*Model, *gotuit.App, *gotuit.View) {
+	screen := tcell.NewSimulationScreen("")
+	app, err := gotuit.NewAppWithScreen(screen)
+	if err != nil {
+		b.Fatal(err)
+	}
+	screen.SetSize(300, 100)
+	b.Cleanup(app.Cleanup)
+
+	model := &Model{}
+	created := time.Date(2024, 1, 1, 9, 0, 0, 0, time.Local)
+	for i := range n {
+		text := fmt.Sprintf("Todo number %d !%d #tag%d due:2024-%02d-%02d", n-i, i%9+1, i%7, i%12+1, i%28+1)
+		model.todos = append(model.todos, Todo{text: text, complete: i%3 == 0, created: created.Add(time.Duration(i) * time.Minute)})
+	}
+
+	list := gotuit.NewView("Todo List", 0, 0, 300, 100, model.renderTodos)
+	list.SetPadding(1, 1, 2, 1)
+	app.AddView(list)
+	return model, app, list
+}
+
+func BenchmarkRenderTodos(b *testing.B) {
+	for _, bm := range []struct {
+		name   string
+		filter string
+		sort   SortMode
+		search string
+	}{
+		{name: "plain"},
+		{name: "filter", filter: "#tag3 -is:done"},
+		{name: "sort", sort: SortAlphabetical},
+		{name: "search", search: "number 1"},
+		{name: "all", filter: "-is:done", sort: SortDue, search: "number 1"},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			model, app, list := newBenchmarkList(b, 10000)
+			if bm.filter != "" {
+				filter, err := parseFilter(bm.filter)
+				if err != nil {
+					b.Fatal(err)
+				}
+				model.filter = filter
+			}
+			model.sort = bm.sort
+			if bm.search != "" {
+				model.findSearchMatches(bm.search)
+			}
+			app.Draw()
+
+			b.ResetTimer()
+			for range b.N {
+				list.Invalidate()
+				app.Draw()
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"github.com/FFX01/gettuit/internal/gotuit"
+	"github.com/gdamore/tcell/v2"
+)
+
+// wheelRows is the number of todos a single wheel notch scrolls the "Todo List"
+// view.
+const wheelRows = 3
+
+// todoDrag tracks a todo being dragged to a new position with the mouse.
+type todoDrag struct {
+	active bool
+	moved  bool
+}
+
+func (m *Model) onTodoListMouse(v *gotuit.View, ev gotuit.MouseEvent) error {
+	if v.Mode == gotuit.InputMode {
+		return nil
+	}
+	row, onTodo := m.rowAt(v, ev.Y)
+
+	switch ev.Action {
+	case gotuit.MouseWheelUp, gotuit.MouseWheelDown:
+		m.scrollTodoList(v, ev.Action)
+	case gotuit.MousePress:
+		if ev.Buttons&tcell.Button1 == 0 || !onTodo {
+			return nil
+		}
+		firstLine := ev.Y == 0 || !m.sameRow(v, ev.Y-1, row)
+		v.Cursory = row
+		if v.Mode == gotuit.NormalMode && firstLine && ev.X >= 0 && ev.X < len("[ ]") {
+			return m.onTodoListToggleComplete(v)
+		}
+		m.drag = todoDrag{active: v.Mode == gotuit.NormalMode && m.sort == SortManual}
+	case gotuit.MouseDrag:
+		if !m.drag.active {
+			return nil
+		}
+		if ev.Y < 0 {
+			row = max(m.listOffset-1, 0)
+		} else if !onTodo {
+			row = len(m.visibleTodos()) - 1
+		}
+		m.dragTodo(v, row)
+	case gotuit.MouseRelease:
+		moved := m.drag.moved
+		m.drag = todoDrag{}
+		if moved {
+			return m.SaveToDisk()
+		}
+	}
+	return nil
+}
+
+// sameRow reports whether screen line y of the "Todo List" view shows the todo
+// at row.
+func (m *Model) sameRow(v *gotuit.View, y, row int) bool {
+	other, ok := m.rowAt(v, y)
+	return ok && other == row
+}
+
+func (m *Model) scrollTodoList(v *gotuit.View, action gotuit.MouseAction) {
+	layout := m.layoutTodos(v)
+	if len(layout) == 0 {
+		return
+	}
+	rows := listRows(v)
+	if action == gotuit.MouseWheelUp {
+		m.listOffset -= wheelRows
+	} else {
+		m.listOffset += wheelRows
+	}
+	m.clampOffset(layout, rows)
+
+	// Drag the cursor along so rendering doesn't scroll straight back to it.
+	v.Cursory = max(min(v.Cursory, m.lastVisibleRow(layout, rows)), m.listOffset)
+	m.clampCursor(v)
+}
+
+// dragTodo moves the todo on the cursor to row, one swap at a time so the todos
+// in between keep their order.
+func (m *Model) dragTodo(v *gotuit.View, row int) {
+	row = max(min(row, len(m.visibleTodos())-1), 0)
+	if row == v.Cursory {
+		return
+	}
+	if !m.drag.moved {
+		m.checkpoint()
+		m.drag.moved = true
+	}
+	for v.Cursory < row {
+		m.swapRows(v.Cursory, v.Cursory+1)
+		v.Cursory++
+	}
+	for v.Cursory > row {
+		m.swapRows(v.Cursory, v.Cursory-1)
+		v.Cursory--
+	}
+}
+
+func (m *Model) onSavedViewsMouse(v *gotuit.View, ev gotuit.MouseEvent) error {
+	switch ev.Action {
+	case gotuit.MouseWheelUp:
+		return m.onSavedViewsCursorUp(v)
+	case gotuit.MouseWheelDown:
+		return m.onSavedViewsCursorDown(v)
+	case gotuit.MousePress:
+		if ev.Buttons&tcell.Button1 == 0 || ev.Y < 0 || ev.Y > len(m.savedViews) {
+			return nil
+		}
+		v.Cursory = ev.Y
+		return m.onSavedViewsSelect(v)
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/FFX01/gettuit/internal/gotuit"
+)
+
+// SavedView is a named filter query. Saved views are listed in the "Saved Views"
+// view and selecting one applies its filter to the "Todo List" view.
+type SavedView struct {
+	name   string
+	filter *Filter
+}
+
+type SavedViewSchema struct {
+	Name  string `json:"name"`
+	Query string `json:"query"`
+}
+
+var defaultSavedViews = []SavedViewSchema{
+	{Name: "Today", Query: "due:today"},
+	{Name: "Overdue", Query: "due:overdue"},
+	{Name: "Waiting on others", Query: "#waiting is:open"},
+}
+
+func (m *Model) loadSavedViews(views []SavedViewSchema) {
+	m.savedViews = []SavedView{}
+	for _, sv := range views {
+		filter, err := parseFilter(sv.Query)
+		if err != nil {
+			log.Printf("Skipping saved view '%s': %s", sv.Name, err)
+			continue
+		}
+		filter.Name = sv.Name
+		m.savedViews = append(m.savedViews, SavedView{name: sv.Name, filter: filter})
+	}
+}
+
+// countMatches returns the number of todos matching filter. A nil filter matches
+// every todo.
+func (m *Model) countMatches(filter *Filter) int {
+	count := 0
+	today := startOfDay(time.Now())
+	for _, t := range m.todos {
+		if filter == nil || filter.Match(t, today) {
+			count++
+		}
+	}
+	return count
+}
+
+// renderSavedViews lists every saved view preceded by an "All" entry. Counts are
+// computed on every render so they always reflect the current todos.
+func (m *Model) renderSavedViews(v *gotuit.View) {
+	entries := []string{fmt.Sprintf("All (%d)", m.countMatches(nil))}
+	for _, sv := range m.savedViews {
+		entries = append(entries, fmt.Sprintf("%s (%d)", sv.name, m.countMatches(sv.filter)))
+	}
+
+	theme := v.Theme()
+	for idx, entry := range entries {
+		style := theme.Style(gotuit.RoleNormal)
+		prefix := "  "
+		if m.isActiveSavedView(idx) {
+			prefix = "> "
+		}
+		if idx == v.Cursory && v.IsFocused() {
+			style = theme.Style(gotuit.RoleSelection)
+		}
+		v.SetTextContent(0, idx, prefix+entry, style)
+	}
+}
+
+// isActiveSavedView reports whether the entry at row of the "Saved Views" view
+// is the one currently filtering the "Todo List" view. Row 0 is "All".
+func (m *Model) isActiveSavedView(row int) bool {
+	if row == 0 {
+		return m.filter == nil
+	}
+	return m.filter != nil && m.filter == m.savedViews[row-1].filter
+}
+
+func (m *Model) onSavedViewsCursorDown(v *gotuit.View) error {
+	if v.Cursory < len(m.savedViews) {
+		v.Cursory++
+	}
+	return nil
+}
+
+func (m *Model) onSavedViewsCursorUp(v *gotuit.View) error {
+	if v.Cursory > 0 {
+		v.Cursory--
+	}
+	return nil
+}
+
+func (m *Model) onSavedViewsSelect(v *gotuit.View) error {
+	list, err := getView(v.App, "Todo List")
+	if err != nil {
+		return err
+	}
+
+	if v.Cursory == 0 {
+		m.filter = nil
+	} else {
+		m.filter = m.savedViews[v.Cursory-1].filter
+	}
+	m.state.Changed()
+	list.Cursory = 0
+	m.clampCursor(list)
+	return m.onSavedViewsExit(v)
+}
+
+func (m *Model) onSavedViewsDelete(v *gotuit.View) error {
+	if v.Cursory == 0 {
+		return nil
+	}
+
+	sv := m.savedViews[v.Cursory-1]
+	if m.filter == sv.filter {
+		m.filter = nil
+	}
+	m.savedViews = slices.Delete(m.savedViews, v.Cursory-1, v.Cursory)
+	v.Cursory--
+	return m.SaveToDisk()
+}
+
+func (m *Model) onSavedViewsExit(v *gotuit.View) error {
+	return v.App.Focus("Todo List")
+}
+
+func (m *Model) onTodoListFocusSavedViews(v *gotuit.View) error {
+	return v.App.Focus("Saved Views")
+}
+
+// onTodoListSaveView prompts for a name to save the active filter under.
+func (m *Model) onTodoListSaveView(v *gotuit.View) error {
+	if m.filter == nil {
+		log.Println("No filter to save")
+		return nil
+	}
+	m.prompt = promptSaveView
+	_, err := m.openPrompt(v)
+	return err
+}
+
+func (m *Model) saveView(name string) error {
+	if name == "" || m.filter == nil {
+		return nil
+	}
+
+	filter := *m.filter
+	filter.Name = name
+	m.savedViews = append(m.savedViews, SavedView{name: name, filter: &filter})
+	m.filter = &filter
+	return m.SaveToDisk()
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/FFX01/gettuit/internal/gotuit"
+)
+
+// SortMode determines the order todos are displayed in. Sorting only affects the
+// "Todo List" view, m.todos always keeps the manual order.
+type SortMode int
+
+const (
+	SortManual SortMode = iota
+	SortAlphabetical
+	SortCreated
+	SortDue
+	SortPriority
+	SortCompletion
+)
+
+var sortModeNames = map[SortMode]string{
+	SortManual:       "Manual",
+	SortAlphabetical: "Alphabetical",
+	SortCreated:      "Created",
+	SortDue:          "Due",
+	SortPriority:     "Priority",
+	SortCompletion:   "Completion",
+}
+
+func (s SortMode) String() string {
+	return sortModeNames[s]
+}
+
+// next returns the sort mode following s, wrapping back around to SortManual.
+func (s SortMode) next() SortMode {
+	if s == SortCompletion {
+		return SortManual
+	}
+	return s + 1
+}
+
+// sortTodos sorts indexes into todos according to mode. The sort is stable so
+// todos which compare equal keep their manual order. Sort keys which take work
+// to compute, like the due date, are computed once per todo up front.
+func sortTodos(todos []Todo, indexes []int, mode SortMode) {
+	var compare func(a, b int) int
+	switch mode {
+	case SortAlphabetical:
+		lower := make([]string, len(todos))
+		for _, idx := range indexes {
+			lower[idx] = strings.ToLower(todos[idx].text)
+		}
+		compare = func(a, b int) int {
+			return strings.Compare(lower[a], lower[b])
+		}
+	case SortCreated:
+		compare = func(a, b int) int {
+			ac, bc := todos[a].created, todos[b].created
+			return compareOptionalTime(ac, !ac.IsZero(), bc, !bc.IsZero())
+		}
+	case SortDue:
+		due := make([]time.Time, len(todos))
+		hasDue := make([]bool, len(todos))
+		for _, idx := range indexes {
+			due[idx], hasDue[idx] = todos[idx].due()
+		}
+		compare = func(a, b int) int {
+			return compareOptionalTime(due[a], hasDue[a], due[b], hasDue[b])
+		}
+	case SortPriority:
+		priority := make([]int, len(todos))
+		hasPriority := make([]bool, len(todos))
+		for _, idx := range indexes {
+			priority[idx], hasPriority[idx] = todos[idx].priority()
+		}
+		compare = func(a, b int) int {
+			if hasPriority[a] != hasPriority[b] {
+				return compareMissingLast(hasPriority[a])
+			}
+			return cmp.Compare(priority[a], priority[b])
+		}
+	case SortCompletion:
+		compare = func(a, b int) int {
+			if todos[a].complete == todos[b].complete {
+				return 0
+			}
+			if todos[a].complete {
+				return 1
+			}
+			return -1
+		}
+	default:
+		return
+	}
+
+	slices.SortStableFunc(indexes, compare)
+}
+
+// compareOptionalTime orders earlier times first and missing times last.
+func compareOptionalTime(a time.Time, aok bool, b time.Time, bok bool) int {
+	if aok != bok {
+		return compareMissingLast(aok)
+	}
+	return a.Compare(b)
+}
+
+func compareMissingLast(aok bool) int {
+	if aok {
+		return -1
+	}
+	return 1
+}
+
+func (m *Model) onTodoListCycleSort(v *gotuit.View) error {
+	m.sort = m.sort.next()
+	m.state.Changed()
+	m.clampCursor(v)
+	return nil
+}
+
+func (m *Model) onTodoListManualSort(v *gotuit.View) error {
+	m.sort = SortManual
+	m.state.Changed()
+	return nil
+}
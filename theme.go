@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"maps"
+	"slices"
+
+	"github.com/FFX01/gettuit/internal/gotuit"
+)
+
+// loadThemes collects the built in and user defined themes, returning the one
+// selected in config.
+func (m *Model) loadThemes(config Config) (*gotuit.Theme, error) {
+	m.themes = gotuit.BuiltinThemes()
+	for _, name := range slices.Sorted(maps.Keys(config.Themes)) {
+		theme, err := gotuit.NewTheme(name, gotuit.DefaultTheme(), config.Themes[name])
+		if err != nil {
+			return nil, err
+		}
+		m.themes = append(m.themes, theme)
+	}
+
+	if config.Theme == "" {
+		return m.themes[0], nil
+	}
+	for _, theme := range m.themes {
+		if theme.Name == config.Theme {
+			return theme, nil
+		}
+	}
+	return nil, fmt.Errorf("Unknown theme '%s'", config.Theme)
+}
+
+func (m *Model) onGlobalCycleTheme(app *gotuit.App) error {
+	idx := slices.IndexFunc(m.themes, func(t *gotuit.Theme) bool {
+		return t.Name == app.Theme().Name
+	})
+	theme := m.themes[(idx+1)%len(m.themes)]
+	app.SetTheme(theme)
+	log.Println("Theme:", theme.Name)
+	return nil
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"log"
+	"slices"
+
+	"github.com/FFX01/gettuit/internal/gotuit"
+)
+
+const maxUndo = 100
+
+// checkpoint records the current todos so the next change can be undone. Call it
+// once before each user facing operation, however many todos it touches.
+func (m *Model) checkpoint() {
+	m.state.Changed()
+	snapshot := slices.Clone(m.todos)
+	m.undoStack = append(m.undoStack, snapshot)
+	if len(m.undoStack) > maxUndo {
+		m.undoStack = m.undoStack[1:]
+	}
+}
+
+func (m *Model) onTodoListUndo(v *gotuit.View) error {
+	if len(m.undoStack) < 1 {
+		log.Println("Nothing to undo")
+		return nil
+	}
+
+	snapshot := m.undoStack[len(m.undoStack)-1]
+	m.undoStack = m.undoStack[:len(m.undoStack)-1]
+
+	// Checkpoints taken while a todo was being edited still hold the temp todo.
+	todos := []Todo{}
+	for _, t := range snapshot {
+		if t.temp && t.text == "" {
+			continue
+		}
+		t.temp = false
+		todos = append(todos, t)
+	}
+	m.todos = todos
+	m.clampCursor(v)
+	log.Println("Undo")
+	return m.SaveToDisk()
+}
@@ -0,0 +1,339 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/FFX01/gettuit/internal/gotuit"
+)
+
+// Selection tracks the todos selected in visual mode. A selection is the union of
+// a contiguous range of rows, from anchor to the cursor, and individually marked
+// todos.
+type Selection struct {
+	ranged bool
+	anchor int
+	marked map[int]bool
+}
+
+// selectedRows returns the selected rows of the "Todo List" view in ascending
+// order.
+func (m *Model) selectedRows(v *gotuit.View) []int {
+	rows := []int{}
+	for row, idx := range m.visibleTodos() {
+		if m.isSelected(v, row, idx) {
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+func (m *Model) isSelected(v *gotuit.View, row, idx int) bool {
+	if v.Mode != gotuit.VisualMode {
+		return false
+	}
+	if m.selection.marked[idx] {
+		return true
+	}
+	if !m.selection.ranged {
+		return false
+	}
+	lo, hi := min(m.selection.anchor, v.Cursory), max(m.selection.anchor, v.Cursory)
+	return row >= lo && row <= hi
+}
+
+// selectedTodos returns the indexes into m.todos of every selected todo.
+func (m *Model) selectedTodos(v *gotuit.View) []int {
+	visible := m.visibleTodos()
+	indexes := []int{}
+	for _, row := range m.selectedRows(v) {
+		indexes = append(indexes, visible[row])
+	}
+	return indexes
+}
+
+func (m *Model) enterVisualMode(v *gotuit.View) {
+	v.Mode = gotuit.VisualMode
+	m.selection = Selection{marked: map[int]bool{}}
+}
+
+func (m *Model) onTodoListVisualMode(v *gotuit.View) error {
+	m.enterVisualMode(v)
+	m.selection.ranged = true
+	m.selection.anchor = v.Cursory
+	return nil
+}
+
+func (m *Model) onTodoListMark(v *gotuit.View) error {
+	if v.Mode != gotuit.VisualMode {
+		m.enterVisualMode(v)
+	}
+
+	idx, ok := m.todoIndex(v.Cursory)
+	if !ok {
+		return nil
+	}
+	if m.selection.marked[idx] {
+		delete(m.selection.marked, idx)
+	} else {
+		m.selection.marked[idx] = true
+	}
+	return nil
+}
+
+// onVisualToggleRange starts a range at the cursor, or ends the current range
+// keeping its rows marked.
+func (m *Model) onVisualToggleRange(v *gotuit.View) error {
+	if m.selection.ranged {
+		for _, idx := range m.selectedTodos(v) {
+			m.selection.marked[idx] = true
+		}
+		m.selection.ranged = false
+		return nil
+	}
+	m.selection.ranged = true
+	m.selection.anchor = v.Cursory
+	return nil
+}
+
+func (m *Model) exitVisualMode(v *gotuit.View) {
+	v.Mode = gotuit.NormalMode
+	m.selection = Selection{}
+}
+
+func (m *Model) onVisualExit(v *gotuit.View) error {
+	m.exitVisualMode(v)
+	return nil
+}
+
+// onVisualToggleComplete completes every selected todo, or reopens them all if
+// they are already complete.
+func (m *Model) onVisualToggleComplete(v *gotuit.View) error {
+	selected := m.selectedTodos(v)
+	if len(selected) < 1 {
+		return nil
+	}
+
+	complete := slices.ContainsFunc(selected, func(idx int) bool {
+		return !m.todos[idx].complete
+	})
+
+	m.checkpoint()
+	for _, idx := range selected {
+		m.todos[idx].complete = complete
+	}
+	m.exitVisualMode(v)
+	m.clampCursor(v)
+	return m.SaveToDisk()
+}
+
+func (m *Model) onVisualDelete(v *gotuit.View) error {
+	selected := m.selectedTodos(v)
+	if len(selected) < 1 {
+		return nil
+	}
+
+	message := fmt.Sprintf("Delete %d selected todos?", len(selected))
+	return m.confirm(v.App, "Delete", message, func() error {
+		return m.deleteSelection(v, selected)
+	})
+}
+
+func (m *Model) deleteSelection(v *gotuit.View, selected []int) error {
+	m.checkpoint()
+	m.deleteTodos(selected)
+	m.exitVisualMode(v)
+	m.clampCursor(v)
+	log.Printf("Deleted %d todos", len(selected))
+	return m.SaveToDisk()
+}
+
+func (m *Model) onVisualMoveDown(v *gotuit.View) error {
+	return m.moveSelection(v, 1)
+}
+
+func (m *Model) onVisualMoveUp(v *gotuit.View) error {
+	return m.moveSelection(v, -1)
+}
+
+// moveSelection moves every selected row by delta, keeping selected rows which
+// are blocked by the top or bottom of the list in place. The selection is kept
+// so the rows can be moved again.
+func (m *Model) moveSelection(v *gotuit.View, delta int) error {
+	if !m.canReorder() {
+		return nil
+	}
+
+	rows := m.selectedRows(v)
+	if len(rows) < 1 {
+		return nil
+	}
+	if delta > 0 {
+		slices.Reverse(rows)
+	}
+
+	count := len(m.visibleTodos())
+	selected := map[int]bool{}
+	for _, row := range rows {
+		selected[row] = true
+	}
+
+	m.checkpoint()
+	cursorMoved := false
+	for _, row := range rows {
+		target := row + delta
+		if target < 0 || target >= count || selected[target] {
+			continue
+		}
+		m.swapRows(row, target)
+		delete(selected, row)
+		selected[target] = true
+		if row == v.Cursory {
+			cursorMoved = true
+		}
+	}
+	if cursorMoved {
+		v.Cursory += delta
+	}
+
+	visible := m.visibleTodos()
+	m.selection.ranged = false
+	m.selection.marked = map[int]bool{}
+	for row := range selected {
+		m.selection.marked[visible[row]] = true
+	}
+	return m.SaveToDisk()
+}
+
+func (m *Model) onVisualTag(v *gotuit.View) error {
+	if len(m.selectedTodos(v)) < 1 {
+		return nil
+	}
+	m.prompt = promptTag
+	_, err := m.openPrompt(v)
+	return err
+}
+
+func (m *Model) onVisualPriority(v *gotuit.View) error {
+	if len(m.selectedTodos(v)) < 1 {
+		return nil
+	}
+	m.prompt = promptPriority
+	_, err := m.openPrompt(v)
+	return err
+}
+
+// tagSelection appends '#tag' to every selected todo which doesn't already have
+// the tag.
+func (m *Model) tagSelection(v *gotuit.View, tag string) error {
+	tag = strings.TrimPrefix(strings.TrimSpace(tag), "#")
+	if tag == "" || strings.ContainsAny(tag, " \t") {
+		return errors.New("Tags can't be empty or contain spaces")
+	}
+
+	m.checkpoint()
+	for _, idx := range m.selectedTodos(v) {
+		if !m.todos[idx].hasTag(strings.ToLower(tag)) {
+			m.todos[idx].text = strings.TrimSpace(m.todos[idx].text + " #" + tag)
+		}
+	}
+	m.exitVisualMode(v)
+	m.clampCursor(v)
+	return m.SaveToDisk()
+}
+
+// prioritizeSelection replaces the '!N' priority of every selected todo. An
+// empty value removes the priority.
+func (m *Model) prioritizeSelection(v *gotuit.View, value string) error {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "!")
+	priority := 0
+	if value != "" {
+		p, err := strconv.Atoi(value)
+		if err != nil || p < 1 || p > 9 {
+			return errors.New("Priority must be a number from 1 to 9")
+		}
+		priority = p
+	}
+
+	m.checkpoint()
+	for _, idx := range m.selectedTodos(v) {
+		m.todos[idx].text = withPriority(m.todos[idx].text, priority)
+	}
+	m.exitVisualMode(v)
+	m.clampCursor(v)
+	return m.SaveToDisk()
+}
+
+// withPriority returns text with its first '!N' word replaced by '!priority', or
+// removed if priority is 0. Any further '!N' words are removed, and '!priority'
+// is appended if there was none. Everything else in text is kept as it was,
+// except the whitespace in front of a removed word.
+func withPriority(text string, priority int) string {
+	token := ""
+	if priority > 0 {
+		token = fmt.Sprintf("!%d", priority)
+	}
+
+	var b strings.Builder
+	last := 0
+	replaced := false
+	for _, word := range wordSpans(text) {
+		if _, ok := (Todo{text: text[word.start:word.end]}).priority(); !ok {
+			continue
+		}
+		if token != "" && !replaced {
+			b.WriteString(text[last:word.start])
+			b.WriteString(token)
+			replaced = true
+		} else {
+			// Drop the whitespace in front of the word, or after it if nothing
+			// comes before it.
+			before := strings.TrimRightFunc(text[last:word.start], unicode.IsSpace)
+			b.WriteString(before)
+			if b.Len() == 0 {
+				word.end += len(text[word.end:]) - len(strings.TrimLeftFunc(text[word.end:], unicode.IsSpace))
+			}
+		}
+		last = word.end
+	}
+	b.WriteString(text[last:])
+
+	result := b.String()
+	if token != "" && !replaced {
+		if strings.TrimRightFunc(result, unicode.IsSpace) == result && result != "" {
+			result += " "
+		}
+		result += token
+	}
+	return result
+}
+
+// span is the byte range start:end of a string.
+type span struct {
+	start, end int
+}
+
+// wordSpans returns the byte ranges of the words strings.Fields splits text into.
+func wordSpans(text string) []span {
+	spans := []span{}
+	start := -1
+	for i, r := range text {
+		if unicode.IsSpace(r) {
+			if start >= 0 {
+				spans = append(spans, span{start, i})
+				start = -1
+			}
+		} else if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		spans = append(spans, span{start, len(text)})
+	}
+	return spans
+}
@@ -0,0 +1,27 @@
+package main
+
+import "testing"
+
+func TestWithPriority(t *testing.T) {
+	for _, tc := range []struct {
+		text     string
+		priority int
+		want     string
+	}{
+		{"buy  milk", 2, "buy  milk !2"},
+		{"buy\tmilk  ", 2, "buy\tmilk  !2"},
+		{"", 2, "!2"},
+		{"buy  !1\tmilk", 3, "buy  !3\tmilk"},
+		{"buy  !1\tmilk", 0, "buy\tmilk"},
+		{"!1  buy   milk", 0, "buy   milk"},
+		{"  !1 buy", 0, "buy"},
+		{"!1 !2  buy", 0, "buy"},
+		{"buy !1  milk !2", 4, "buy !4  milk"},
+		{"buy !10 milk!1", 5, "buy !10 milk!1 !5"},
+		{"buy milk", 0, "buy milk"},
+	} {
+		if got := withPriority(tc.text, tc.priority); got != tc.want {
+			t.Errorf("withPriority(%q, %d) = %q, want %q", tc.text, tc.priority, got, tc.want)
+		}
+	}
+}